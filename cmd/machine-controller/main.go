@@ -28,6 +28,7 @@ import (
 func main() {
 	s := options.NewMCServer()
 	s.AddFlags(pflag.CommandLine)
+	asyncServerCreation := pflag.CommandLine.Bool("async-server-creation", false, "Return from the machine creation as soon as the server has been accepted by Nova and track the server build during the machine initialization. Machine classes with fallback availability zones always wait for the server build.")
 
 	flag.InitFlags()
 	logs.InitLogs()
@@ -38,7 +39,7 @@ func main() {
 		klog.Fatalf("failed to install scheme: %v", err)
 	}
 
-	provider := driver.NewOpenstackDriver(serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder())
+	provider := driver.NewOpenstackDriver(serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(), *asyncServerCreation)
 
 	if err := app.Run(s, provider); err != nil {
 		klog.Fatalf("failed to run application: %v", err)
@@ -46,4 +46,29 @@ const (
 
 	// UserData is a constant for a key name whose value contains data passed to the server e.g. CloudInit scripts.
 	UserData string = "userData"
+
+	// VNICTypeNormal is the vNIC type of ports bound to a virtual switch.
+	VNICTypeNormal = "normal"
+	// VNICTypeDirect is the vNIC type of SR-IOV ports passing a virtual function through to the server.
+	VNICTypeDirect = "direct"
+	// VNICTypeDirectPhysical is the vNIC type of SR-IOV ports passing a physical function through to the server.
+	VNICTypeDirectPhysical = "direct-physical"
+	// VNICTypeMacvtap is the vNIC type of SR-IOV ports attached to the server via a MacVTap device.
+	VNICTypeMacvtap = "macvtap"
+	// VNICTypeVirtioForwarder is the vNIC type of ports attached via a virtio relay of a SmartNIC.
+	VNICTypeVirtioForwarder = "virtio-forwarder"
+	// VNICTypeVDPA is the vNIC type of ports attached via a vDPA device.
+	VNICTypeVDPA = "vdpa"
+
+	// RootVolumeDeletionPolicyDelete deletes the root volume together with the machine.
+	RootVolumeDeletionPolicyDelete = "Delete"
+	// RootVolumeDeletionPolicyRetain keeps the root volume when the machine is deleted.
+	RootVolumeDeletionPolicyRetain = "Retain"
+	// RootVolumeDeletionPolicyBackup creates a Cinder backup of the root volume before it is deleted together with the machine.
+	RootVolumeDeletionPolicyBackup = "Backup"
+
+	// BlockDeviceTypeEphemeral is the type of blank local disks backed by the ephemeral disk space of the flavor.
+	BlockDeviceTypeEphemeral = "ephemeral"
+	// BlockDeviceTypeSwap is the type of local swap disks backed by the swap space of the flavor.
+	BlockDeviceTypeSwap = "swap"
 )
@@ -23,14 +23,39 @@ type MachineProviderConfigSpec struct {
 	ImageID string
 	// ImageName is the name of the image used the machine. If ImageID is specified, it takes priority over ImageName.
 	ImageName string
+	// ImageSelector selects the newest active image matching the given criteria. It can be used instead of ImageID and
+	// ImageName.
+	ImageSelector *ImageSelector
+	// TrustedImageCertificates are the IDs of the certificates used to verify the signature of the image. Requires
+	// compute API microversion 2.63 and can not be used when booting from volume.
+	TrustedImageCertificates []string
 	// Region is the region the machine should belong to.
 	Region string
 	// AvailabilityZone is the availability zone the machine belongs.
 	AvailabilityZone string
+	// FallbackAvailabilityZones are availability zones which are tried in the given order if no valid host can be found
+	// for the machine in AvailabilityZone. The server creation waits for the server build even if it is asynchronous
+	// otherwise. The availability zone the server was created in is encoded in its provider ID.
+	FallbackAvailabilityZones []string
 	// FlavorName is the flavor of the machine.
 	FlavorName string
+	// FlavorNames are fallback flavors which are tried in the given order if the flavor given by FlavorName does not
+	// exist or no valid host can be found for it.
+	FlavorNames []string
+	// FlavorSelector selects the smallest flavors meeting the given requirements. It can be used instead of FlavorName.
+	FlavorSelector *FlavorSelector
 	// KeyName is the name of the key pair used for SSH access.
 	KeyName string
+	// Hostname is a template for the hostname of the instance, which can differ from its name. The template can use
+	// {{ .MachineName }}, {{ .MachineClassName }} and {{ .Region }}. The node name of the machine is derived from the
+	// hostname. Requires compute API microversion 2.90.
+	Hostname string
+	// FQDN is a template for the fully qualified domain name set as hostname of the instance. It can use the same values
+	// as Hostname and can not be combined with it. Requires compute API microversion 2.94.
+	FQDN string
+	// Description is a template for the description of the instance. It can use the same values as Hostname. Requires
+	// compute API microversion 2.19.
+	Description string
 	// SecurityGroups is a list of security groups the instance should belong to.
 	SecurityGroups []string
 	// Tags is a map of key-value pairs that annotate the instance. Tags are stored in the instance's Metadata field.
@@ -47,20 +72,67 @@ type MachineProviderConfigSpec struct {
 	PodNetworkCidr string
 	// PodNetworkCidr is the CIDR ranges for the pods assigned to this instance.
 	PodNetworkCIDRs []string
+	// AllowedAddressPairs is a list of additional address pairs, e.g. virtual IP addresses, that are allowed on the ports
+	// in the pod network besides the pod network CIDRs.
+	AllowedAddressPairs []AllowedAddressPair
 	// The size of the root disk used for the instance.
 	RootDiskSize int
 	// The type of the root disk type used for the instance
 	RootDiskType *string
+	// RootVolumeSource boots the instance from a root volume created from a Cinder snapshot or cloned from an existing
+	// volume instead of an image. RootVolumeSource is mutually exclusive with ImageID, ImageName and ImageSelector.
+	RootVolumeSource *RootVolumeSource
+	// RootVolumeDeletionPolicy specifies what happens to a root volume managed by MCM when the machine is deleted, i.e.
+	// one of "Delete", "Retain" or "Backup". "Backup" creates a Cinder backup of the volume before it is deleted, which
+	// requires the Cinder backup service. A policy other than "Delete" makes MCM manage the root volume and requires
+	// RootDiskSize or RootVolumeSource. Defaults to "Delete".
+	RootVolumeDeletionPolicy string
 	// UseConfigDrive enables the use of configuration drives for the instance.
 	UseConfigDrive *bool
+	// UserDataOptions configures how user data exceeding the size limit of Nova is passed to the instance.
+	UserDataOptions *UserDataOptions
 	// ServerGroupID is the ID of the server group this instance should belong to.
 	ServerGroupID *string
+	// ServerGroup is a server group managed by MCM the instance is placed in. The server group is created if it does not
+	// exist and deleted once no instance is left in it. ServerGroup is mutually exclusive with ServerGroupID.
+	ServerGroup *ServerGroup
+	// SchedulerHints are passed to the Nova scheduler to control the placement of the instance.
+	SchedulerHints *SchedulerHints
+	// Host pins the instance to the compute host with the supplied name. Requires compute API microversion 2.74 and is
+	// usually restricted to administrators.
+	Host string
+	// HypervisorHostname pins the instance to the hypervisor with the supplied hostname. Requires compute API
+	// microversion 2.74 and is usually restricted to administrators.
+	HypervisorHostname string
 	// Networks is a list of networks the instance should belong to. Networks is mutually exclusive with the NetworkID option
 	// and only one should be specified.
 	Networks []OpenStackNetwork
+	// ManagedPorts specifies whether MCM creates the ports of the Networks before the server and deletes them together
+	// with the machine. Otherwise the ports are created by Nova. The port options of the Networks and a Trunk require
+	// managed ports.
+	ManagedPorts bool
+	// BareMetal enables the bare-metal mode for instances backed by Ironic nodes.
+	BareMetal *BareMetal
+	// DataVolumes is a list of additional volumes that are attached to the instance.
+	DataVolumes []DataVolume
+	// BlockDevices is a list of additional local disks of the instance, i.e. blank ephemeral disks and swap. They are
+	// checked against the ephemeral and swap sizes of the flavor before the instance is created.
+	BlockDevices []BlockDevice
+	// VolumeAvailabilityZone is the Cinder availability zone the root and data volumes are created in. Defaults to the
+	// availability zone of the instance.
+	VolumeAvailabilityZone string
+	// VolumeSchedulerHints are passed to the Cinder scheduler when the root and data volumes are created.
+	VolumeSchedulerHints *VolumeSchedulerHints
+	// VolumeMetadata is additional metadata set on the root and data volumes.
+	VolumeMetadata map[string]string
+	// FloatingIP configures the floating IP that is associated with the instance.
+	FloatingIP *FloatingIP
+	// Trunk turns the primary port of the instance into a Neutron trunk carrying the given VLAN subports.
+	Trunk *Trunk
 }
 
-// OpenStackNetwork describes a network this instance should belong to.
+// OpenStackNetwork describes a network this instance should belong to. The options of the port of the instance, starting
+// with SubnetIDs, require ManagedPorts.
 type OpenStackNetwork struct {
 	// Id is the ID of a network the instance should belong to.
 	Id string
@@ -68,4 +140,202 @@ type OpenStackNetwork struct {
 	Name string
 	// PodNetwork specifies whether this network is part of the pod network.
 	PodNetwork bool
+	// Primary specifies whether the addresses of this network are reported first. Defaults to the first pod network.
+	Primary bool
+	// SubnetIDs is a list of IDs of the subnets the port of the instance should get an IP address from.
+	SubnetIDs []string
+	// FixedIPs is a list of fixed IP addresses the port of the instance should get.
+	FixedIPs []FixedIP
+	// SecurityGroups is a list of security groups the port of the instance should belong to. If not specified, the
+	// SecurityGroups of the instance are used.
+	SecurityGroups []string
+	// PortNameSuffix is the suffix appended to the machine name to form the name of the port. Defaults to the index of the
+	// network in the list.
+	PortNameSuffix string
+	// DisablePortSecurity disables the port security of the port of the instance. Security groups can not be used for
+	// ports without port security.
+	DisablePortSecurity bool
+	// AllowedAddressPairs is a list of additional address pairs, e.g. virtual IP addresses, that are allowed on the port
+	// of the instance. They can not be used for ports without port security.
+	AllowedAddressPairs []AllowedAddressPair
+	// VNICType is the type of the virtual network interface card the port of the instance is bound to, e.g. "direct" or
+	// "direct-physical" for SR-IOV ports. Defaults to "normal".
+	VNICType string
+	// BindingProfile is a map of key-value pairs passed as binding profile to the networking back-end that binds the port
+	// of the instance.
+	BindingProfile map[string]string
+}
+
+// FixedIP describes a fixed IP address of a port.
+type FixedIP struct {
+	// SubnetID is the ID of the subnet the fixed IP address belongs to.
+	SubnetID string
+	// IPAddress is the fixed IP address. If not specified, an IP address of the subnet is allocated.
+	IPAddress string
+}
+
+// AllowedAddressPair describes an address pair that is allowed on a port in addition to the fixed IP addresses of the
+// port.
+type AllowedAddressPair struct {
+	// IPAddress is the IP address or CIDR range that is allowed.
+	IPAddress string
+	// MACAddress is the MAC address the pair is scoped to. Defaults to the MAC address of the port.
+	MACAddress string
+}
+
+// DataVolume describes an additional Cinder volume attached to the instance.
+type DataVolume struct {
+	// Name is the suffix appended to the machine name to form the name of the volume.
+	Name string
+	// Size is the size of the volume in GB.
+	Size int
+	// Type is the volume type of the volume.
+	Type *string
+	// DeleteOnTermination specifies whether the volume is deleted together with the machine. Defaults to true.
+	DeleteOnTermination *bool
+}
+
+// FloatingIP describes the floating IP that is associated with the instance.
+type FloatingIP struct {
+	// NetworkID is the ID of the external network the floating IP is allocated from.
+	NetworkID string
+	// NetworkName is the name of the external network the floating IP is allocated from. If NetworkID is specified, it
+	// takes priority over NetworkName.
+	NetworkName string
+	// SubnetID is the ID of the external subnet the floating IP is allocated from.
+	SubnetID *string
+	// Pool is a list of pre-allocated floating IP addresses. If specified, a free floating IP address of the pool is
+	// associated with the instance instead of allocating a new one. Floating IPs of the pool are not released when the
+	// machine is deleted.
+	Pool []string
+}
+
+// Trunk describes the Neutron trunk of the primary port of the instance.
+type Trunk struct {
+	// SubPorts are the VLAN subports of the trunk.
+	SubPorts []SubPort
+}
+
+// SubPort describes a VLAN subport of a trunk.
+type SubPort struct {
+	// NetworkID is the ID of the network the subport is created in.
+	NetworkID string
+	// NetworkName is the name of the network the subport is created in. If NetworkID is specified, it takes priority
+	// over NetworkName.
+	NetworkName string
+	// SegmentationID is the VLAN ID the traffic of the subport is tagged with.
+	SegmentationID int
+}
+
+// ImageSelector describes the criteria an image has to match to be used by the machine. If multiple images match, the
+// most recently created one is used.
+type ImageSelector struct {
+	// Name is the name of the image.
+	Name string
+	// Properties are the image properties, e.g. os_distro or os_version, the image has to carry with the given values.
+	Properties map[string]string
+	// Tags are the tags the image has to carry.
+	Tags []string
+	// Owner is the ID of the project owning the image.
+	Owner string
+	// Visibility is the visibility of the image, i.e. one of "public", "private", "shared" or "community".
+	Visibility string
+}
+
+// FlavorSelector describes the requirements a flavor has to meet to be used by the machine. Matching flavors are tried
+// from the smallest to the largest one.
+type FlavorSelector struct {
+	// MinVCPUs is the minimum number of vCPUs of the flavor.
+	MinVCPUs int
+	// MinRAM is the minimum amount of memory of the flavor in MiB.
+	MinRAM int
+	// MinDisk is the minimum size of the root disk of the flavor in GiB.
+	MinDisk int
+	// ExtraSpecs are the extra specs the flavor has to carry with the given values.
+	ExtraSpecs map[string]string
+}
+
+// SchedulerHints describes the hints passed to the Nova scheduler when the instance is created.
+type SchedulerHints struct {
+	// DifferentHost places the instance on a host which does not host any of the instances with the given IDs.
+	DifferentHost []string
+	// SameHost places the instance on a host which hosts the instances with the given IDs.
+	SameHost []string
+	// Query is a JSON encoded conditional statement the host of the instance has to fulfill, e.g.
+	// [">=", "$free_ram_mb", 1024].
+	Query string
+	// TargetCell is the name of the cell the instance is placed in.
+	TargetCell string
+	// DifferentCell are the names of the cells the instance is not placed in.
+	DifferentCell []string
+	// BuildNearHostIP places the instance on a host within the given subnet, e.g. "192.168.1.0/24".
+	BuildNearHostIP string
+}
+
+// ServerGroup describes a server group managed by MCM.
+type ServerGroup struct {
+	// Name is the name of the server group.
+	Name string
+	// Policy is the scheduling policy of the server group, i.e. one of "affinity", "anti-affinity", "soft-affinity" or
+	// "soft-anti-affinity".
+	Policy string
+}
+
+// VolumeSchedulerHints describes the hints passed to the Cinder scheduler when a volume is created.
+type VolumeSchedulerHints struct {
+	// DifferentHost places the volume on a back-end which does not host any of the volumes with the given IDs.
+	DifferentHost []string
+	// SameHost places the volume on a back-end which hosts the volumes with the given IDs.
+	SameHost []string
+	// LocalToInstance places the volume on the host of the instance with the given ID.
+	LocalToInstance string
+}
+
+// RootVolumeSource describes the source of the root volume of the instance. Exactly one of the fields must be set.
+type RootVolumeSource struct {
+	// SnapshotID is the ID of the Cinder snapshot the root volume is created from.
+	SnapshotID string
+	// SnapshotName is the name of the Cinder snapshot the root volume is created from.
+	SnapshotName string
+	// VolumeID is the ID of the Cinder volume the root volume is cloned from.
+	VolumeID string
+	// VolumeName is the name of the Cinder volume the root volume is cloned from.
+	VolumeName string
+}
+
+// BlockDevice describes a local disk of the instance.
+type BlockDevice struct {
+	// Type is the type of the disk, i.e. "ephemeral" or "swap".
+	Type string
+	// Size is the size of the disk in GB for ephemeral disks and in MB for swap, like the sizes of the flavor. Defaults
+	// to the ephemeral or swap size of the flavor.
+	Size int
+	// GuestFormat is the filesystem an ephemeral disk is formatted with, e.g. "ext4".
+	GuestFormat string
+	// DeviceName is the requested name of the device in the instance, e.g. "/dev/vdb". Some hypervisors ignore it.
+	DeviceName string
+}
+
+// UserDataOptions describes how user data exceeding the size limit of Nova is passed to the instance. Oversized user
+// data is gzip compressed first, which cloud-init decompresses transparently. If it is still too large, it is staged in
+// Swift and the instance receives a cloud-init include stub pointing to a temporary URL of the object.
+type UserDataOptions struct {
+	// Compress enables the gzip compression of oversized user data. Defaults to true.
+	Compress *bool
+	// SwiftContainer is the existing Swift container oversized user data is staged in. The Swift account or container
+	// requires a temp URL key. If no container is given, user data is not staged.
+	SwiftContainer string
+	// TempURLValidity is the validity of the temporary URL of staged user data. Defaults to 24h.
+	TempURLValidity *metav1.Duration
+}
+
+// BareMetal describes the bare-metal mode for instances backed by Ironic nodes. In bare-metal mode the instance uses a
+// config drive unless disabled, allowed address pairs are only set on networks with port security and the deletion
+// waits until the Ironic node has been cleaned and released. The latter requires the nodes to be visible to the project,
+// e.g. as owner or lessee, otherwise it is skipped.
+type BareMetal struct {
+	// DeployTimeout is the maximum duration of the deployment of the node. Defaults to 1h.
+	DeployTimeout *metav1.Duration
+	// CleaningTimeout is the maximum duration of the tear down and cleaning of the node. Defaults to 1h.
+	CleaningTimeout *metav1.Duration
 }
@@ -25,14 +25,47 @@ type MachineProviderConfigSpec struct {
 	ImageID string `json:"imageID"`
 	// ImageName is the name of the image used the machine. If ImageID is specified, it takes priority over ImageName.
 	ImageName string `json:"imageName"`
+	// ImageSelector selects the newest active image matching the given criteria. It can be used instead of ImageID and
+	// ImageName.
+	// +optional
+	ImageSelector *ImageSelector `json:"imageSelector,omitempty"`
+	// TrustedImageCertificates are the IDs of the certificates used to verify the signature of the image. Requires
+	// compute API microversion 2.63 and can not be used when booting from volume.
+	// +optional
+	TrustedImageCertificates []string `json:"trustedImageCertificates,omitempty"`
 	// Region is the region the machine should belong to.
 	Region string `json:"region"`
 	// AvailabilityZone is the availability zone the machine belongs.
 	AvailabilityZone string `json:"availabilityZone"`
+	// FallbackAvailabilityZones are availability zones which are tried in the given order if no valid host can be found
+	// for the machine in AvailabilityZone. The server creation waits for the server build even if it is asynchronous
+	// otherwise. The availability zone the server was created in is encoded in its provider ID.
+	// +optional
+	FallbackAvailabilityZones []string `json:"fallbackAvailabilityZones,omitempty"`
 	// FlavorName is the flavor of the machine.
 	FlavorName string `json:"flavorName"`
+	// FlavorNames are fallback flavors which are tried in the given order if the flavor given by FlavorName does not
+	// exist or no valid host can be found for it.
+	// +optional
+	FlavorNames []string `json:"flavorNames,omitempty"`
+	// FlavorSelector selects the smallest flavors meeting the given requirements. It can be used instead of FlavorName.
+	// +optional
+	FlavorSelector *FlavorSelector `json:"flavorSelector,omitempty"`
 	// KeyName is the name of the key pair used for SSH access.
 	KeyName string `json:"keyName"`
+	// Hostname is a template for the hostname of the instance, which can differ from its name. The template can use
+	// {{ .MachineName }}, {{ .MachineClassName }} and {{ .Region }}. The node name of the machine is derived from the
+	// hostname. Requires compute API microversion 2.90.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// FQDN is a template for the fully qualified domain name set as hostname of the instance. It can use the same values
+	// as Hostname and can not be combined with it. Requires compute API microversion 2.94.
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+	// Description is a template for the description of the instance. It can use the same values as Hostname. Requires
+	// compute API microversion 2.19.
+	// +optional
+	Description string `json:"description,omitempty"`
 	// SecurityGroups is a list of security groups the instance should belong to.
 	SecurityGroups []string `json:"securityGroups"`
 	// Tags is a map of key-value pairs that annotate the instance. Tags are stored in the instance's Metadata field.
@@ -53,22 +86,86 @@ type MachineProviderConfigSpec struct {
 	// PodNetworkCIDRs is the CIDR ranges for the pods assigned to this instance.
 	// +optional
 	PodNetworkCIDRs []string `json:"podNetworkCIDRs"`
+	// AllowedAddressPairs is a list of additional address pairs, e.g. virtual IP addresses, that are allowed on the ports
+	// in the pod network besides the pod network CIDRs.
+	// +optional
+	AllowedAddressPairs []AllowedAddressPair `json:"allowedAddressPairs,omitempty"`
 	// The size of the root disk used for the instance.
 	RootDiskSize int `json:"rootDiskSize,omitempty"` // in GB
 	// The type of the root disk used for the instance.
 	// +optional
 	RootDiskType *string `json:"rootDiskType,omitempty"`
+	// RootVolumeSource boots the instance from a root volume created from a Cinder snapshot or cloned from an existing
+	// volume instead of an image. RootVolumeSource is mutually exclusive with ImageID, ImageName and ImageSelector.
+	// +optional
+	RootVolumeSource *RootVolumeSource `json:"rootVolumeSource,omitempty"`
+	// RootVolumeDeletionPolicy specifies what happens to a root volume managed by MCM when the machine is deleted, i.e.
+	// one of "Delete", "Retain" or "Backup". "Backup" creates a Cinder backup of the volume before it is deleted, which
+	// requires the Cinder backup service. A policy other than "Delete" makes MCM manage the root volume and requires
+	// RootDiskSize or RootVolumeSource. Defaults to "Delete".
+	// +optional
+	RootVolumeDeletionPolicy string `json:"rootVolumeDeletionPolicy,omitempty"`
 	// UseConfigDrive enables the use of configuration drives for the instance.
 	UseConfigDrive *bool `json:"useConfigDrive,omitempty"`
+	// UserDataOptions configures how user data exceeding the size limit of Nova is passed to the instance.
+	// +optional
+	UserDataOptions *UserDataOptions `json:"userDataOptions,omitempty"`
 	// ServerGroupID is the ID of the server group this instance should belong to.
 	// +optional
 	ServerGroupID *string `json:"serverGroupID,omitempty"`
+	// ServerGroup is a server group managed by MCM the instance is placed in. The server group is created if it does not
+	// exist and deleted once no instance is left in it. ServerGroup is mutually exclusive with ServerGroupID.
+	// +optional
+	ServerGroup *ServerGroup `json:"serverGroup,omitempty"`
+	// SchedulerHints are passed to the Nova scheduler to control the placement of the instance.
+	// +optional
+	SchedulerHints *SchedulerHints `json:"schedulerHints,omitempty"`
+	// Host pins the instance to the compute host with the supplied name. Requires compute API microversion 2.74 and is
+	// usually restricted to administrators.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// HypervisorHostname pins the instance to the hypervisor with the supplied hostname. Requires compute API
+	// microversion 2.74 and is usually restricted to administrators.
+	// +optional
+	HypervisorHostname string `json:"hypervisorHostname,omitempty"`
 	// Networks is a list of networks the instance should belong to. Networks is mutually exclusive with the NetworkID option
 	// and only one should be specified.
 	Networks []OpenStackNetwork `json:"networks,omitempty"`
+	// ManagedPorts specifies whether MCM creates the ports of the Networks before the server and deletes them together
+	// with the machine. Otherwise the ports are created by Nova. The port options of the Networks and a Trunk require
+	// managed ports. Existing machines keep the ports created by Nova, only new machines get managed ports.
+	// +optional
+	ManagedPorts bool `json:"managedPorts,omitempty"`
+	// BareMetal enables the bare-metal mode for instances backed by Ironic nodes.
+	// +optional
+	BareMetal *BareMetal `json:"bareMetal,omitempty"`
+	// DataVolumes is a list of additional volumes that are attached to the instance.
+	// +optional
+	DataVolumes []DataVolume `json:"dataVolumes,omitempty"`
+	// BlockDevices is a list of additional local disks of the instance, i.e. blank ephemeral disks and swap. They are
+	// checked against the ephemeral and swap sizes of the flavor before the instance is created.
+	// +optional
+	BlockDevices []BlockDevice `json:"blockDevices,omitempty"`
+	// VolumeAvailabilityZone is the Cinder availability zone the root and data volumes are created in. Defaults to the
+	// availability zone of the instance.
+	// +optional
+	VolumeAvailabilityZone string `json:"volumeAvailabilityZone,omitempty"`
+	// VolumeSchedulerHints are passed to the Cinder scheduler when the root and data volumes are created.
+	// +optional
+	VolumeSchedulerHints *VolumeSchedulerHints `json:"volumeSchedulerHints,omitempty"`
+	// VolumeMetadata is additional metadata set on the root and data volumes.
+	// +optional
+	VolumeMetadata map[string]string `json:"volumeMetadata,omitempty"`
+	// FloatingIP configures the floating IP that is associated with the instance.
+	// +optional
+	FloatingIP *FloatingIP `json:"floatingIP,omitempty"`
+	// Trunk turns the primary port of the instance into a Neutron trunk carrying the given VLAN subports.
+	// +optional
+	Trunk *Trunk `json:"trunk,omitempty"`
 }
 
-// OpenStackNetwork describes a network this instance should belong to.
+// OpenStackNetwork describes a network this instance should belong to. The options of the port of the instance, starting
+// with SubnetIDs, require ManagedPorts.
 type OpenStackNetwork struct {
 	// Id is the ID of a network the instance should belong to.
 	Id string `json:"id,omitempty"` // takes priority before name
@@ -76,4 +173,252 @@ type OpenStackNetwork struct {
 	Name string `json:"name,omitempty"`
 	// PodNetwork specifies whether this network is part of the pod network.
 	PodNetwork bool `json:"podNetwork,omitempty"`
+	// Primary specifies whether the addresses of this network are reported first. Defaults to the first pod network.
+	// +optional
+	Primary bool `json:"primary,omitempty"`
+	// SubnetIDs is a list of IDs of the subnets the port of the instance should get an IP address from.
+	// +optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+	// FixedIPs is a list of fixed IP addresses the port of the instance should get.
+	// +optional
+	FixedIPs []FixedIP `json:"fixedIPs,omitempty"`
+	// SecurityGroups is a list of security groups the port of the instance should belong to. If not specified, the
+	// SecurityGroups of the instance are used.
+	// +optional
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+	// PortNameSuffix is the suffix appended to the machine name to form the name of the port. Defaults to the index of the
+	// network in the list.
+	// +optional
+	PortNameSuffix string `json:"portNameSuffix,omitempty"`
+	// DisablePortSecurity disables the port security of the port of the instance. Security groups can not be used for
+	// ports without port security.
+	// +optional
+	DisablePortSecurity bool `json:"disablePortSecurity,omitempty"`
+	// AllowedAddressPairs is a list of additional address pairs, e.g. virtual IP addresses, that are allowed on the port
+	// of the instance. They can not be used for ports without port security.
+	// +optional
+	AllowedAddressPairs []AllowedAddressPair `json:"allowedAddressPairs,omitempty"`
+	// VNICType is the type of the virtual network interface card the port of the instance is bound to, e.g. "direct" or
+	// "direct-physical" for SR-IOV ports. Defaults to "normal".
+	// +optional
+	VNICType string `json:"vnicType,omitempty"`
+	// BindingProfile is a map of key-value pairs passed as binding profile to the networking back-end that binds the port
+	// of the instance.
+	// +optional
+	BindingProfile map[string]string `json:"bindingProfile,omitempty"`
+}
+
+// FixedIP describes a fixed IP address of a port.
+type FixedIP struct {
+	// SubnetID is the ID of the subnet the fixed IP address belongs to.
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+	// IPAddress is the fixed IP address. If not specified, an IP address of the subnet is allocated.
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// AllowedAddressPair describes an address pair that is allowed on a port in addition to the fixed IP addresses of the
+// port.
+type AllowedAddressPair struct {
+	// IPAddress is the IP address or CIDR range that is allowed.
+	IPAddress string `json:"ipAddress"`
+	// MACAddress is the MAC address the pair is scoped to. Defaults to the MAC address of the port.
+	// +optional
+	MACAddress string `json:"macAddress,omitempty"`
+}
+
+// DataVolume describes an additional Cinder volume attached to the instance.
+type DataVolume struct {
+	// Name is the suffix appended to the machine name to form the name of the volume.
+	Name string `json:"name"`
+	// Size is the size of the volume in GB.
+	Size int `json:"size"`
+	// Type is the volume type of the volume.
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// DeleteOnTermination specifies whether the volume is deleted together with the machine. Defaults to true.
+	// +optional
+	DeleteOnTermination *bool `json:"deleteOnTermination,omitempty"`
+}
+
+// FloatingIP describes the floating IP that is associated with the instance.
+type FloatingIP struct {
+	// NetworkID is the ID of the external network the floating IP is allocated from.
+	// +optional
+	NetworkID string `json:"networkID,omitempty"`
+	// NetworkName is the name of the external network the floating IP is allocated from. If NetworkID is specified, it
+	// takes priority over NetworkName.
+	// +optional
+	NetworkName string `json:"networkName,omitempty"`
+	// SubnetID is the ID of the external subnet the floating IP is allocated from.
+	// +optional
+	SubnetID *string `json:"subnetID,omitempty"`
+	// Pool is a list of pre-allocated floating IP addresses. If specified, a free floating IP address of the pool is
+	// associated with the instance instead of allocating a new one. Floating IPs of the pool are not released when the
+	// machine is deleted.
+	// +optional
+	Pool []string `json:"pool,omitempty"`
+}
+
+// Trunk describes the Neutron trunk of the primary port of the instance.
+type Trunk struct {
+	// SubPorts are the VLAN subports of the trunk.
+	SubPorts []SubPort `json:"subPorts"`
+}
+
+// SubPort describes a VLAN subport of a trunk.
+type SubPort struct {
+	// NetworkID is the ID of the network the subport is created in.
+	// +optional
+	NetworkID string `json:"networkID,omitempty"`
+	// NetworkName is the name of the network the subport is created in. If NetworkID is specified, it takes priority
+	// over NetworkName.
+	// +optional
+	NetworkName string `json:"networkName,omitempty"`
+	// SegmentationID is the VLAN ID the traffic of the subport is tagged with.
+	SegmentationID int `json:"segmentationID"`
+}
+
+// ImageSelector describes the criteria an image has to match to be used by the machine. If multiple images match, the
+// most recently created one is used.
+type ImageSelector struct {
+	// Name is the name of the image.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Properties are the image properties, e.g. os_distro or os_version, the image has to carry with the given values.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+	// Tags are the tags the image has to carry.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Owner is the ID of the project owning the image.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// Visibility is the visibility of the image, i.e. one of "public", "private", "shared" or "community".
+	// +optional
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// FlavorSelector describes the requirements a flavor has to meet to be used by the machine. Matching flavors are tried
+// from the smallest to the largest one.
+type FlavorSelector struct {
+	// MinVCPUs is the minimum number of vCPUs of the flavor.
+	// +optional
+	MinVCPUs int `json:"minVCPUs,omitempty"`
+	// MinRAM is the minimum amount of memory of the flavor in MiB.
+	// +optional
+	MinRAM int `json:"minRAM,omitempty"`
+	// MinDisk is the minimum size of the root disk of the flavor in GiB.
+	// +optional
+	MinDisk int `json:"minDisk,omitempty"`
+	// ExtraSpecs are the extra specs the flavor has to carry with the given values.
+	// +optional
+	ExtraSpecs map[string]string `json:"extraSpecs,omitempty"`
+}
+
+// SchedulerHints describes the hints passed to the Nova scheduler when the instance is created.
+type SchedulerHints struct {
+	// DifferentHost places the instance on a host which does not host any of the instances with the given IDs.
+	// +optional
+	DifferentHost []string `json:"differentHost,omitempty"`
+	// SameHost places the instance on a host which hosts the instances with the given IDs.
+	// +optional
+	SameHost []string `json:"sameHost,omitempty"`
+	// Query is a JSON encoded conditional statement the host of the instance has to fulfill, e.g.
+	// [">=", "$free_ram_mb", 1024].
+	// +optional
+	Query string `json:"query,omitempty"`
+	// TargetCell is the name of the cell the instance is placed in.
+	// +optional
+	TargetCell string `json:"targetCell,omitempty"`
+	// DifferentCell are the names of the cells the instance is not placed in.
+	// +optional
+	DifferentCell []string `json:"differentCell,omitempty"`
+	// BuildNearHostIP places the instance on a host within the given subnet, e.g. "192.168.1.0/24".
+	// +optional
+	BuildNearHostIP string `json:"buildNearHostIP,omitempty"`
+}
+
+// ServerGroup describes a server group managed by MCM.
+type ServerGroup struct {
+	// Name is the name of the server group.
+	Name string `json:"name"`
+	// Policy is the scheduling policy of the server group, i.e. one of "affinity", "anti-affinity", "soft-affinity" or
+	// "soft-anti-affinity".
+	Policy string `json:"policy"`
+}
+
+// VolumeSchedulerHints describes the hints passed to the Cinder scheduler when a volume is created.
+type VolumeSchedulerHints struct {
+	// DifferentHost places the volume on a back-end which does not host any of the volumes with the given IDs.
+	// +optional
+	DifferentHost []string `json:"differentHost,omitempty"`
+	// SameHost places the volume on a back-end which hosts the volumes with the given IDs.
+	// +optional
+	SameHost []string `json:"sameHost,omitempty"`
+	// LocalToInstance places the volume on the host of the instance with the given ID.
+	// +optional
+	LocalToInstance string `json:"localToInstance,omitempty"`
+}
+
+// RootVolumeSource describes the source of the root volume of the instance. Exactly one of the fields must be set.
+type RootVolumeSource struct {
+	// SnapshotID is the ID of the Cinder snapshot the root volume is created from.
+	// +optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// SnapshotName is the name of the Cinder snapshot the root volume is created from.
+	// +optional
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// VolumeID is the ID of the Cinder volume the root volume is cloned from.
+	// +optional
+	VolumeID string `json:"volumeID,omitempty"`
+	// VolumeName is the name of the Cinder volume the root volume is cloned from.
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+}
+
+// BlockDevice describes a local disk of the instance.
+type BlockDevice struct {
+	// Type is the type of the disk, i.e. "ephemeral" or "swap".
+	Type string `json:"type"`
+	// Size is the size of the disk in GB for ephemeral disks and in MB for swap, like the sizes of the flavor. Defaults
+	// to the ephemeral or swap size of the flavor.
+	// +optional
+	Size int `json:"size,omitempty"`
+	// GuestFormat is the filesystem an ephemeral disk is formatted with, e.g. "ext4".
+	// +optional
+	GuestFormat string `json:"guestFormat,omitempty"`
+	// DeviceName is the requested name of the device in the instance, e.g. "/dev/vdb". Some hypervisors ignore it.
+	// +optional
+	DeviceName string `json:"deviceName,omitempty"`
+}
+
+// UserDataOptions describes how user data exceeding the size limit of Nova is passed to the instance. Oversized user
+// data is gzip compressed first, which cloud-init decompresses transparently. If it is still too large, it is staged in
+// Swift and the instance receives a cloud-init include stub pointing to a temporary URL of the object.
+type UserDataOptions struct {
+	// Compress enables the gzip compression of oversized user data. Defaults to true.
+	// +optional
+	Compress *bool `json:"compress,omitempty"`
+	// SwiftContainer is the existing Swift container oversized user data is staged in. The Swift account or container
+	// requires a temp URL key. If no container is given, user data is not staged.
+	// +optional
+	SwiftContainer string `json:"swiftContainer,omitempty"`
+	// TempURLValidity is the validity of the temporary URL of staged user data. Defaults to 24h.
+	// +optional
+	TempURLValidity *metav1.Duration `json:"tempURLValidity,omitempty"`
+}
+
+// BareMetal describes the bare-metal mode for instances backed by Ironic nodes. In bare-metal mode the instance uses a
+// config drive unless disabled, allowed address pairs are only set on networks with port security and the deletion
+// waits until the Ironic node has been cleaned and released. The latter requires the nodes to be visible to the project,
+// e.g. as owner or lessee, otherwise it is skipped.
+type BareMetal struct {
+	// DeployTimeout is the maximum duration of the deployment of the node. Defaults to 1h.
+	// +optional
+	DeployTimeout *metav1.Duration `json:"deployTimeout,omitempty"`
+	// CleaningTimeout is the maximum duration of the tear down and cleaning of the node. Defaults to 1h.
+	// +optional
+	CleaningTimeout *metav1.Duration `json:"cleaningTimeout,omitempty"`
 }
@@ -13,6 +13,7 @@ import (
 	unsafe "unsafe"
 
 	openstack "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -24,6 +25,86 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AllowedAddressPair)(nil), (*openstack.AllowedAddressPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AllowedAddressPair_To_openstack_AllowedAddressPair(a.(*AllowedAddressPair), b.(*openstack.AllowedAddressPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.AllowedAddressPair)(nil), (*AllowedAddressPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_AllowedAddressPair_To_v1alpha1_AllowedAddressPair(a.(*openstack.AllowedAddressPair), b.(*AllowedAddressPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BareMetal)(nil), (*openstack.BareMetal)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BareMetal_To_openstack_BareMetal(a.(*BareMetal), b.(*openstack.BareMetal), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.BareMetal)(nil), (*BareMetal)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_BareMetal_To_v1alpha1_BareMetal(a.(*openstack.BareMetal), b.(*BareMetal), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BlockDevice)(nil), (*openstack.BlockDevice)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BlockDevice_To_openstack_BlockDevice(a.(*BlockDevice), b.(*openstack.BlockDevice), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.BlockDevice)(nil), (*BlockDevice)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_BlockDevice_To_v1alpha1_BlockDevice(a.(*openstack.BlockDevice), b.(*BlockDevice), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DataVolume)(nil), (*openstack.DataVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DataVolume_To_openstack_DataVolume(a.(*DataVolume), b.(*openstack.DataVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.DataVolume)(nil), (*DataVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_DataVolume_To_v1alpha1_DataVolume(a.(*openstack.DataVolume), b.(*DataVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FixedIP)(nil), (*openstack.FixedIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FixedIP_To_openstack_FixedIP(a.(*FixedIP), b.(*openstack.FixedIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.FixedIP)(nil), (*FixedIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_FixedIP_To_v1alpha1_FixedIP(a.(*openstack.FixedIP), b.(*FixedIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlavorSelector)(nil), (*openstack.FlavorSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlavorSelector_To_openstack_FlavorSelector(a.(*FlavorSelector), b.(*openstack.FlavorSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.FlavorSelector)(nil), (*FlavorSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_FlavorSelector_To_v1alpha1_FlavorSelector(a.(*openstack.FlavorSelector), b.(*FlavorSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FloatingIP)(nil), (*openstack.FloatingIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FloatingIP_To_openstack_FloatingIP(a.(*FloatingIP), b.(*openstack.FloatingIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.FloatingIP)(nil), (*FloatingIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_FloatingIP_To_v1alpha1_FloatingIP(a.(*openstack.FloatingIP), b.(*FloatingIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageSelector)(nil), (*openstack.ImageSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageSelector_To_openstack_ImageSelector(a.(*ImageSelector), b.(*openstack.ImageSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.ImageSelector)(nil), (*ImageSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_ImageSelector_To_v1alpha1_ImageSelector(a.(*openstack.ImageSelector), b.(*ImageSelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MachineProviderConfig)(nil), (*openstack.MachineProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MachineProviderConfig_To_openstack_MachineProviderConfig(a.(*MachineProviderConfig), b.(*openstack.MachineProviderConfig), scope)
 	}); err != nil {
@@ -54,9 +135,277 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RootVolumeSource)(nil), (*openstack.RootVolumeSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RootVolumeSource_To_openstack_RootVolumeSource(a.(*RootVolumeSource), b.(*openstack.RootVolumeSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.RootVolumeSource)(nil), (*RootVolumeSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_RootVolumeSource_To_v1alpha1_RootVolumeSource(a.(*openstack.RootVolumeSource), b.(*RootVolumeSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SchedulerHints)(nil), (*openstack.SchedulerHints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SchedulerHints_To_openstack_SchedulerHints(a.(*SchedulerHints), b.(*openstack.SchedulerHints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.SchedulerHints)(nil), (*SchedulerHints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_SchedulerHints_To_v1alpha1_SchedulerHints(a.(*openstack.SchedulerHints), b.(*SchedulerHints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServerGroup)(nil), (*openstack.ServerGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ServerGroup_To_openstack_ServerGroup(a.(*ServerGroup), b.(*openstack.ServerGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.ServerGroup)(nil), (*ServerGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_ServerGroup_To_v1alpha1_ServerGroup(a.(*openstack.ServerGroup), b.(*ServerGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubPort)(nil), (*openstack.SubPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubPort_To_openstack_SubPort(a.(*SubPort), b.(*openstack.SubPort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.SubPort)(nil), (*SubPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_SubPort_To_v1alpha1_SubPort(a.(*openstack.SubPort), b.(*SubPort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Trunk)(nil), (*openstack.Trunk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Trunk_To_openstack_Trunk(a.(*Trunk), b.(*openstack.Trunk), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.Trunk)(nil), (*Trunk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_Trunk_To_v1alpha1_Trunk(a.(*openstack.Trunk), b.(*Trunk), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*UserDataOptions)(nil), (*openstack.UserDataOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_UserDataOptions_To_openstack_UserDataOptions(a.(*UserDataOptions), b.(*openstack.UserDataOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.UserDataOptions)(nil), (*UserDataOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_UserDataOptions_To_v1alpha1_UserDataOptions(a.(*openstack.UserDataOptions), b.(*UserDataOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeSchedulerHints)(nil), (*openstack.VolumeSchedulerHints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VolumeSchedulerHints_To_openstack_VolumeSchedulerHints(a.(*VolumeSchedulerHints), b.(*openstack.VolumeSchedulerHints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.VolumeSchedulerHints)(nil), (*VolumeSchedulerHints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_VolumeSchedulerHints_To_v1alpha1_VolumeSchedulerHints(a.(*openstack.VolumeSchedulerHints), b.(*VolumeSchedulerHints), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AllowedAddressPair_To_openstack_AllowedAddressPair(in *AllowedAddressPair, out *openstack.AllowedAddressPair, s conversion.Scope) error {
+	out.IPAddress = in.IPAddress
+	out.MACAddress = in.MACAddress
+	return nil
+}
+
+// Convert_v1alpha1_AllowedAddressPair_To_openstack_AllowedAddressPair is an autogenerated conversion function.
+func Convert_v1alpha1_AllowedAddressPair_To_openstack_AllowedAddressPair(in *AllowedAddressPair, out *openstack.AllowedAddressPair, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AllowedAddressPair_To_openstack_AllowedAddressPair(in, out, s)
+}
+
+func autoConvert_openstack_AllowedAddressPair_To_v1alpha1_AllowedAddressPair(in *openstack.AllowedAddressPair, out *AllowedAddressPair, s conversion.Scope) error {
+	out.IPAddress = in.IPAddress
+	out.MACAddress = in.MACAddress
+	return nil
+}
+
+// Convert_openstack_AllowedAddressPair_To_v1alpha1_AllowedAddressPair is an autogenerated conversion function.
+func Convert_openstack_AllowedAddressPair_To_v1alpha1_AllowedAddressPair(in *openstack.AllowedAddressPair, out *AllowedAddressPair, s conversion.Scope) error {
+	return autoConvert_openstack_AllowedAddressPair_To_v1alpha1_AllowedAddressPair(in, out, s)
+}
+
+func autoConvert_v1alpha1_BareMetal_To_openstack_BareMetal(in *BareMetal, out *openstack.BareMetal, s conversion.Scope) error {
+	out.DeployTimeout = (*v1.Duration)(unsafe.Pointer(in.DeployTimeout))
+	out.CleaningTimeout = (*v1.Duration)(unsafe.Pointer(in.CleaningTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_BareMetal_To_openstack_BareMetal is an autogenerated conversion function.
+func Convert_v1alpha1_BareMetal_To_openstack_BareMetal(in *BareMetal, out *openstack.BareMetal, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BareMetal_To_openstack_BareMetal(in, out, s)
+}
+
+func autoConvert_openstack_BareMetal_To_v1alpha1_BareMetal(in *openstack.BareMetal, out *BareMetal, s conversion.Scope) error {
+	out.DeployTimeout = (*v1.Duration)(unsafe.Pointer(in.DeployTimeout))
+	out.CleaningTimeout = (*v1.Duration)(unsafe.Pointer(in.CleaningTimeout))
+	return nil
+}
+
+// Convert_openstack_BareMetal_To_v1alpha1_BareMetal is an autogenerated conversion function.
+func Convert_openstack_BareMetal_To_v1alpha1_BareMetal(in *openstack.BareMetal, out *BareMetal, s conversion.Scope) error {
+	return autoConvert_openstack_BareMetal_To_v1alpha1_BareMetal(in, out, s)
+}
+
+func autoConvert_v1alpha1_BlockDevice_To_openstack_BlockDevice(in *BlockDevice, out *openstack.BlockDevice, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Size = in.Size
+	out.GuestFormat = in.GuestFormat
+	out.DeviceName = in.DeviceName
+	return nil
+}
+
+// Convert_v1alpha1_BlockDevice_To_openstack_BlockDevice is an autogenerated conversion function.
+func Convert_v1alpha1_BlockDevice_To_openstack_BlockDevice(in *BlockDevice, out *openstack.BlockDevice, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BlockDevice_To_openstack_BlockDevice(in, out, s)
+}
+
+func autoConvert_openstack_BlockDevice_To_v1alpha1_BlockDevice(in *openstack.BlockDevice, out *BlockDevice, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Size = in.Size
+	out.GuestFormat = in.GuestFormat
+	out.DeviceName = in.DeviceName
+	return nil
+}
+
+// Convert_openstack_BlockDevice_To_v1alpha1_BlockDevice is an autogenerated conversion function.
+func Convert_openstack_BlockDevice_To_v1alpha1_BlockDevice(in *openstack.BlockDevice, out *BlockDevice, s conversion.Scope) error {
+	return autoConvert_openstack_BlockDevice_To_v1alpha1_BlockDevice(in, out, s)
+}
+
+func autoConvert_v1alpha1_DataVolume_To_openstack_DataVolume(in *DataVolume, out *openstack.DataVolume, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Size = in.Size
+	out.Type = (*string)(unsafe.Pointer(in.Type))
+	out.DeleteOnTermination = (*bool)(unsafe.Pointer(in.DeleteOnTermination))
+	return nil
+}
+
+// Convert_v1alpha1_DataVolume_To_openstack_DataVolume is an autogenerated conversion function.
+func Convert_v1alpha1_DataVolume_To_openstack_DataVolume(in *DataVolume, out *openstack.DataVolume, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DataVolume_To_openstack_DataVolume(in, out, s)
+}
+
+func autoConvert_openstack_DataVolume_To_v1alpha1_DataVolume(in *openstack.DataVolume, out *DataVolume, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Size = in.Size
+	out.Type = (*string)(unsafe.Pointer(in.Type))
+	out.DeleteOnTermination = (*bool)(unsafe.Pointer(in.DeleteOnTermination))
+	return nil
+}
+
+// Convert_openstack_DataVolume_To_v1alpha1_DataVolume is an autogenerated conversion function.
+func Convert_openstack_DataVolume_To_v1alpha1_DataVolume(in *openstack.DataVolume, out *DataVolume, s conversion.Scope) error {
+	return autoConvert_openstack_DataVolume_To_v1alpha1_DataVolume(in, out, s)
+}
+
+func autoConvert_v1alpha1_FixedIP_To_openstack_FixedIP(in *FixedIP, out *openstack.FixedIP, s conversion.Scope) error {
+	out.SubnetID = in.SubnetID
+	out.IPAddress = in.IPAddress
+	return nil
+}
+
+// Convert_v1alpha1_FixedIP_To_openstack_FixedIP is an autogenerated conversion function.
+func Convert_v1alpha1_FixedIP_To_openstack_FixedIP(in *FixedIP, out *openstack.FixedIP, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FixedIP_To_openstack_FixedIP(in, out, s)
+}
+
+func autoConvert_openstack_FixedIP_To_v1alpha1_FixedIP(in *openstack.FixedIP, out *FixedIP, s conversion.Scope) error {
+	out.SubnetID = in.SubnetID
+	out.IPAddress = in.IPAddress
+	return nil
+}
+
+// Convert_openstack_FixedIP_To_v1alpha1_FixedIP is an autogenerated conversion function.
+func Convert_openstack_FixedIP_To_v1alpha1_FixedIP(in *openstack.FixedIP, out *FixedIP, s conversion.Scope) error {
+	return autoConvert_openstack_FixedIP_To_v1alpha1_FixedIP(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlavorSelector_To_openstack_FlavorSelector(in *FlavorSelector, out *openstack.FlavorSelector, s conversion.Scope) error {
+	out.MinVCPUs = in.MinVCPUs
+	out.MinRAM = in.MinRAM
+	out.MinDisk = in.MinDisk
+	out.ExtraSpecs = *(*map[string]string)(unsafe.Pointer(&in.ExtraSpecs))
+	return nil
+}
+
+// Convert_v1alpha1_FlavorSelector_To_openstack_FlavorSelector is an autogenerated conversion function.
+func Convert_v1alpha1_FlavorSelector_To_openstack_FlavorSelector(in *FlavorSelector, out *openstack.FlavorSelector, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlavorSelector_To_openstack_FlavorSelector(in, out, s)
+}
+
+func autoConvert_openstack_FlavorSelector_To_v1alpha1_FlavorSelector(in *openstack.FlavorSelector, out *FlavorSelector, s conversion.Scope) error {
+	out.MinVCPUs = in.MinVCPUs
+	out.MinRAM = in.MinRAM
+	out.MinDisk = in.MinDisk
+	out.ExtraSpecs = *(*map[string]string)(unsafe.Pointer(&in.ExtraSpecs))
+	return nil
+}
+
+// Convert_openstack_FlavorSelector_To_v1alpha1_FlavorSelector is an autogenerated conversion function.
+func Convert_openstack_FlavorSelector_To_v1alpha1_FlavorSelector(in *openstack.FlavorSelector, out *FlavorSelector, s conversion.Scope) error {
+	return autoConvert_openstack_FlavorSelector_To_v1alpha1_FlavorSelector(in, out, s)
+}
+
+func autoConvert_v1alpha1_FloatingIP_To_openstack_FloatingIP(in *FloatingIP, out *openstack.FloatingIP, s conversion.Scope) error {
+	out.NetworkID = in.NetworkID
+	out.NetworkName = in.NetworkName
+	out.SubnetID = (*string)(unsafe.Pointer(in.SubnetID))
+	out.Pool = *(*[]string)(unsafe.Pointer(&in.Pool))
+	return nil
+}
+
+// Convert_v1alpha1_FloatingIP_To_openstack_FloatingIP is an autogenerated conversion function.
+func Convert_v1alpha1_FloatingIP_To_openstack_FloatingIP(in *FloatingIP, out *openstack.FloatingIP, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FloatingIP_To_openstack_FloatingIP(in, out, s)
+}
+
+func autoConvert_openstack_FloatingIP_To_v1alpha1_FloatingIP(in *openstack.FloatingIP, out *FloatingIP, s conversion.Scope) error {
+	out.NetworkID = in.NetworkID
+	out.NetworkName = in.NetworkName
+	out.SubnetID = (*string)(unsafe.Pointer(in.SubnetID))
+	out.Pool = *(*[]string)(unsafe.Pointer(&in.Pool))
+	return nil
+}
+
+// Convert_openstack_FloatingIP_To_v1alpha1_FloatingIP is an autogenerated conversion function.
+func Convert_openstack_FloatingIP_To_v1alpha1_FloatingIP(in *openstack.FloatingIP, out *FloatingIP, s conversion.Scope) error {
+	return autoConvert_openstack_FloatingIP_To_v1alpha1_FloatingIP(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageSelector_To_openstack_ImageSelector(in *ImageSelector, out *openstack.ImageSelector, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Properties = *(*map[string]string)(unsafe.Pointer(&in.Properties))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.Owner = in.Owner
+	out.Visibility = in.Visibility
+	return nil
+}
+
+// Convert_v1alpha1_ImageSelector_To_openstack_ImageSelector is an autogenerated conversion function.
+func Convert_v1alpha1_ImageSelector_To_openstack_ImageSelector(in *ImageSelector, out *openstack.ImageSelector, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageSelector_To_openstack_ImageSelector(in, out, s)
+}
+
+func autoConvert_openstack_ImageSelector_To_v1alpha1_ImageSelector(in *openstack.ImageSelector, out *ImageSelector, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Properties = *(*map[string]string)(unsafe.Pointer(&in.Properties))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.Owner = in.Owner
+	out.Visibility = in.Visibility
 	return nil
 }
 
+// Convert_openstack_ImageSelector_To_v1alpha1_ImageSelector is an autogenerated conversion function.
+func Convert_openstack_ImageSelector_To_v1alpha1_ImageSelector(in *openstack.ImageSelector, out *ImageSelector, s conversion.Scope) error {
+	return autoConvert_openstack_ImageSelector_To_v1alpha1_ImageSelector(in, out, s)
+}
+
 func autoConvert_v1alpha1_MachineProviderConfig_To_openstack_MachineProviderConfig(in *MachineProviderConfig, out *openstack.MachineProviderConfig, s conversion.Scope) error {
 	if err := Convert_v1alpha1_MachineProviderConfigSpec_To_openstack_MachineProviderConfigSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
@@ -84,10 +433,18 @@ func Convert_openstack_MachineProviderConfig_To_v1alpha1_MachineProviderConfig(i
 func autoConvert_v1alpha1_MachineProviderConfigSpec_To_openstack_MachineProviderConfigSpec(in *MachineProviderConfigSpec, out *openstack.MachineProviderConfigSpec, s conversion.Scope) error {
 	out.ImageID = in.ImageID
 	out.ImageName = in.ImageName
+	out.ImageSelector = (*openstack.ImageSelector)(unsafe.Pointer(in.ImageSelector))
+	out.TrustedImageCertificates = *(*[]string)(unsafe.Pointer(&in.TrustedImageCertificates))
 	out.Region = in.Region
 	out.AvailabilityZone = in.AvailabilityZone
+	out.FallbackAvailabilityZones = *(*[]string)(unsafe.Pointer(&in.FallbackAvailabilityZones))
 	out.FlavorName = in.FlavorName
+	out.FlavorNames = *(*[]string)(unsafe.Pointer(&in.FlavorNames))
+	out.FlavorSelector = (*openstack.FlavorSelector)(unsafe.Pointer(in.FlavorSelector))
 	out.KeyName = in.KeyName
+	out.Hostname = in.Hostname
+	out.FQDN = in.FQDN
+	out.Description = in.Description
 	out.SecurityGroups = *(*[]string)(unsafe.Pointer(&in.SecurityGroups))
 	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
 	out.NetworkID = in.NetworkID
@@ -95,11 +452,28 @@ func autoConvert_v1alpha1_MachineProviderConfigSpec_To_openstack_MachineProvider
 	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
 	out.PodNetworkCidr = in.PodNetworkCidr
 	out.PodNetworkCIDRs = *(*[]string)(unsafe.Pointer(&in.PodNetworkCIDRs))
+	out.AllowedAddressPairs = *(*[]openstack.AllowedAddressPair)(unsafe.Pointer(&in.AllowedAddressPairs))
 	out.RootDiskSize = in.RootDiskSize
 	out.RootDiskType = (*string)(unsafe.Pointer(in.RootDiskType))
+	out.RootVolumeSource = (*openstack.RootVolumeSource)(unsafe.Pointer(in.RootVolumeSource))
+	out.RootVolumeDeletionPolicy = in.RootVolumeDeletionPolicy
 	out.UseConfigDrive = (*bool)(unsafe.Pointer(in.UseConfigDrive))
+	out.UserDataOptions = (*openstack.UserDataOptions)(unsafe.Pointer(in.UserDataOptions))
 	out.ServerGroupID = (*string)(unsafe.Pointer(in.ServerGroupID))
+	out.ServerGroup = (*openstack.ServerGroup)(unsafe.Pointer(in.ServerGroup))
+	out.SchedulerHints = (*openstack.SchedulerHints)(unsafe.Pointer(in.SchedulerHints))
+	out.Host = in.Host
+	out.HypervisorHostname = in.HypervisorHostname
 	out.Networks = *(*[]openstack.OpenStackNetwork)(unsafe.Pointer(&in.Networks))
+	out.ManagedPorts = in.ManagedPorts
+	out.BareMetal = (*openstack.BareMetal)(unsafe.Pointer(in.BareMetal))
+	out.DataVolumes = *(*[]openstack.DataVolume)(unsafe.Pointer(&in.DataVolumes))
+	out.BlockDevices = *(*[]openstack.BlockDevice)(unsafe.Pointer(&in.BlockDevices))
+	out.VolumeAvailabilityZone = in.VolumeAvailabilityZone
+	out.VolumeSchedulerHints = (*openstack.VolumeSchedulerHints)(unsafe.Pointer(in.VolumeSchedulerHints))
+	out.VolumeMetadata = *(*map[string]string)(unsafe.Pointer(&in.VolumeMetadata))
+	out.FloatingIP = (*openstack.FloatingIP)(unsafe.Pointer(in.FloatingIP))
+	out.Trunk = (*openstack.Trunk)(unsafe.Pointer(in.Trunk))
 	return nil
 }
 
@@ -111,10 +485,18 @@ func Convert_v1alpha1_MachineProviderConfigSpec_To_openstack_MachineProviderConf
 func autoConvert_openstack_MachineProviderConfigSpec_To_v1alpha1_MachineProviderConfigSpec(in *openstack.MachineProviderConfigSpec, out *MachineProviderConfigSpec, s conversion.Scope) error {
 	out.ImageID = in.ImageID
 	out.ImageName = in.ImageName
+	out.ImageSelector = (*ImageSelector)(unsafe.Pointer(in.ImageSelector))
+	out.TrustedImageCertificates = *(*[]string)(unsafe.Pointer(&in.TrustedImageCertificates))
 	out.Region = in.Region
 	out.AvailabilityZone = in.AvailabilityZone
+	out.FallbackAvailabilityZones = *(*[]string)(unsafe.Pointer(&in.FallbackAvailabilityZones))
 	out.FlavorName = in.FlavorName
+	out.FlavorNames = *(*[]string)(unsafe.Pointer(&in.FlavorNames))
+	out.FlavorSelector = (*FlavorSelector)(unsafe.Pointer(in.FlavorSelector))
 	out.KeyName = in.KeyName
+	out.Hostname = in.Hostname
+	out.FQDN = in.FQDN
+	out.Description = in.Description
 	out.SecurityGroups = *(*[]string)(unsafe.Pointer(&in.SecurityGroups))
 	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
 	out.NetworkID = in.NetworkID
@@ -122,11 +504,28 @@ func autoConvert_openstack_MachineProviderConfigSpec_To_v1alpha1_MachineProvider
 	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
 	out.PodNetworkCidr = in.PodNetworkCidr
 	out.PodNetworkCIDRs = *(*[]string)(unsafe.Pointer(&in.PodNetworkCIDRs))
+	out.AllowedAddressPairs = *(*[]AllowedAddressPair)(unsafe.Pointer(&in.AllowedAddressPairs))
 	out.RootDiskSize = in.RootDiskSize
 	out.RootDiskType = (*string)(unsafe.Pointer(in.RootDiskType))
+	out.RootVolumeSource = (*RootVolumeSource)(unsafe.Pointer(in.RootVolumeSource))
+	out.RootVolumeDeletionPolicy = in.RootVolumeDeletionPolicy
 	out.UseConfigDrive = (*bool)(unsafe.Pointer(in.UseConfigDrive))
+	out.UserDataOptions = (*UserDataOptions)(unsafe.Pointer(in.UserDataOptions))
 	out.ServerGroupID = (*string)(unsafe.Pointer(in.ServerGroupID))
+	out.ServerGroup = (*ServerGroup)(unsafe.Pointer(in.ServerGroup))
+	out.SchedulerHints = (*SchedulerHints)(unsafe.Pointer(in.SchedulerHints))
+	out.Host = in.Host
+	out.HypervisorHostname = in.HypervisorHostname
 	out.Networks = *(*[]OpenStackNetwork)(unsafe.Pointer(&in.Networks))
+	out.ManagedPorts = in.ManagedPorts
+	out.BareMetal = (*BareMetal)(unsafe.Pointer(in.BareMetal))
+	out.DataVolumes = *(*[]DataVolume)(unsafe.Pointer(&in.DataVolumes))
+	out.BlockDevices = *(*[]BlockDevice)(unsafe.Pointer(&in.BlockDevices))
+	out.VolumeAvailabilityZone = in.VolumeAvailabilityZone
+	out.VolumeSchedulerHints = (*VolumeSchedulerHints)(unsafe.Pointer(in.VolumeSchedulerHints))
+	out.VolumeMetadata = *(*map[string]string)(unsafe.Pointer(&in.VolumeMetadata))
+	out.FloatingIP = (*FloatingIP)(unsafe.Pointer(in.FloatingIP))
+	out.Trunk = (*Trunk)(unsafe.Pointer(in.Trunk))
 	return nil
 }
 
@@ -139,6 +538,15 @@ func autoConvert_v1alpha1_OpenStackNetwork_To_openstack_OpenStackNetwork(in *Ope
 	out.Id = in.Id
 	out.Name = in.Name
 	out.PodNetwork = in.PodNetwork
+	out.Primary = in.Primary
+	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
+	out.FixedIPs = *(*[]openstack.FixedIP)(unsafe.Pointer(&in.FixedIPs))
+	out.SecurityGroups = *(*[]string)(unsafe.Pointer(&in.SecurityGroups))
+	out.PortNameSuffix = in.PortNameSuffix
+	out.DisablePortSecurity = in.DisablePortSecurity
+	out.AllowedAddressPairs = *(*[]openstack.AllowedAddressPair)(unsafe.Pointer(&in.AllowedAddressPairs))
+	out.VNICType = in.VNICType
+	out.BindingProfile = *(*map[string]string)(unsafe.Pointer(&in.BindingProfile))
 	return nil
 }
 
@@ -151,6 +559,15 @@ func autoConvert_openstack_OpenStackNetwork_To_v1alpha1_OpenStackNetwork(in *ope
 	out.Id = in.Id
 	out.Name = in.Name
 	out.PodNetwork = in.PodNetwork
+	out.Primary = in.Primary
+	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
+	out.FixedIPs = *(*[]FixedIP)(unsafe.Pointer(&in.FixedIPs))
+	out.SecurityGroups = *(*[]string)(unsafe.Pointer(&in.SecurityGroups))
+	out.PortNameSuffix = in.PortNameSuffix
+	out.DisablePortSecurity = in.DisablePortSecurity
+	out.AllowedAddressPairs = *(*[]AllowedAddressPair)(unsafe.Pointer(&in.AllowedAddressPairs))
+	out.VNICType = in.VNICType
+	out.BindingProfile = *(*map[string]string)(unsafe.Pointer(&in.BindingProfile))
 	return nil
 }
 
@@ -158,3 +575,173 @@ func autoConvert_openstack_OpenStackNetwork_To_v1alpha1_OpenStackNetwork(in *ope
 func Convert_openstack_OpenStackNetwork_To_v1alpha1_OpenStackNetwork(in *openstack.OpenStackNetwork, out *OpenStackNetwork, s conversion.Scope) error {
 	return autoConvert_openstack_OpenStackNetwork_To_v1alpha1_OpenStackNetwork(in, out, s)
 }
+
+func autoConvert_v1alpha1_RootVolumeSource_To_openstack_RootVolumeSource(in *RootVolumeSource, out *openstack.RootVolumeSource, s conversion.Scope) error {
+	out.SnapshotID = in.SnapshotID
+	out.SnapshotName = in.SnapshotName
+	out.VolumeID = in.VolumeID
+	out.VolumeName = in.VolumeName
+	return nil
+}
+
+// Convert_v1alpha1_RootVolumeSource_To_openstack_RootVolumeSource is an autogenerated conversion function.
+func Convert_v1alpha1_RootVolumeSource_To_openstack_RootVolumeSource(in *RootVolumeSource, out *openstack.RootVolumeSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RootVolumeSource_To_openstack_RootVolumeSource(in, out, s)
+}
+
+func autoConvert_openstack_RootVolumeSource_To_v1alpha1_RootVolumeSource(in *openstack.RootVolumeSource, out *RootVolumeSource, s conversion.Scope) error {
+	out.SnapshotID = in.SnapshotID
+	out.SnapshotName = in.SnapshotName
+	out.VolumeID = in.VolumeID
+	out.VolumeName = in.VolumeName
+	return nil
+}
+
+// Convert_openstack_RootVolumeSource_To_v1alpha1_RootVolumeSource is an autogenerated conversion function.
+func Convert_openstack_RootVolumeSource_To_v1alpha1_RootVolumeSource(in *openstack.RootVolumeSource, out *RootVolumeSource, s conversion.Scope) error {
+	return autoConvert_openstack_RootVolumeSource_To_v1alpha1_RootVolumeSource(in, out, s)
+}
+
+func autoConvert_v1alpha1_SchedulerHints_To_openstack_SchedulerHints(in *SchedulerHints, out *openstack.SchedulerHints, s conversion.Scope) error {
+	out.DifferentHost = *(*[]string)(unsafe.Pointer(&in.DifferentHost))
+	out.SameHost = *(*[]string)(unsafe.Pointer(&in.SameHost))
+	out.Query = in.Query
+	out.TargetCell = in.TargetCell
+	out.DifferentCell = *(*[]string)(unsafe.Pointer(&in.DifferentCell))
+	out.BuildNearHostIP = in.BuildNearHostIP
+	return nil
+}
+
+// Convert_v1alpha1_SchedulerHints_To_openstack_SchedulerHints is an autogenerated conversion function.
+func Convert_v1alpha1_SchedulerHints_To_openstack_SchedulerHints(in *SchedulerHints, out *openstack.SchedulerHints, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SchedulerHints_To_openstack_SchedulerHints(in, out, s)
+}
+
+func autoConvert_openstack_SchedulerHints_To_v1alpha1_SchedulerHints(in *openstack.SchedulerHints, out *SchedulerHints, s conversion.Scope) error {
+	out.DifferentHost = *(*[]string)(unsafe.Pointer(&in.DifferentHost))
+	out.SameHost = *(*[]string)(unsafe.Pointer(&in.SameHost))
+	out.Query = in.Query
+	out.TargetCell = in.TargetCell
+	out.DifferentCell = *(*[]string)(unsafe.Pointer(&in.DifferentCell))
+	out.BuildNearHostIP = in.BuildNearHostIP
+	return nil
+}
+
+// Convert_openstack_SchedulerHints_To_v1alpha1_SchedulerHints is an autogenerated conversion function.
+func Convert_openstack_SchedulerHints_To_v1alpha1_SchedulerHints(in *openstack.SchedulerHints, out *SchedulerHints, s conversion.Scope) error {
+	return autoConvert_openstack_SchedulerHints_To_v1alpha1_SchedulerHints(in, out, s)
+}
+
+func autoConvert_v1alpha1_ServerGroup_To_openstack_ServerGroup(in *ServerGroup, out *openstack.ServerGroup, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Policy = in.Policy
+	return nil
+}
+
+// Convert_v1alpha1_ServerGroup_To_openstack_ServerGroup is an autogenerated conversion function.
+func Convert_v1alpha1_ServerGroup_To_openstack_ServerGroup(in *ServerGroup, out *openstack.ServerGroup, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ServerGroup_To_openstack_ServerGroup(in, out, s)
+}
+
+func autoConvert_openstack_ServerGroup_To_v1alpha1_ServerGroup(in *openstack.ServerGroup, out *ServerGroup, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Policy = in.Policy
+	return nil
+}
+
+// Convert_openstack_ServerGroup_To_v1alpha1_ServerGroup is an autogenerated conversion function.
+func Convert_openstack_ServerGroup_To_v1alpha1_ServerGroup(in *openstack.ServerGroup, out *ServerGroup, s conversion.Scope) error {
+	return autoConvert_openstack_ServerGroup_To_v1alpha1_ServerGroup(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubPort_To_openstack_SubPort(in *SubPort, out *openstack.SubPort, s conversion.Scope) error {
+	out.NetworkID = in.NetworkID
+	out.NetworkName = in.NetworkName
+	out.SegmentationID = in.SegmentationID
+	return nil
+}
+
+// Convert_v1alpha1_SubPort_To_openstack_SubPort is an autogenerated conversion function.
+func Convert_v1alpha1_SubPort_To_openstack_SubPort(in *SubPort, out *openstack.SubPort, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubPort_To_openstack_SubPort(in, out, s)
+}
+
+func autoConvert_openstack_SubPort_To_v1alpha1_SubPort(in *openstack.SubPort, out *SubPort, s conversion.Scope) error {
+	out.NetworkID = in.NetworkID
+	out.NetworkName = in.NetworkName
+	out.SegmentationID = in.SegmentationID
+	return nil
+}
+
+// Convert_openstack_SubPort_To_v1alpha1_SubPort is an autogenerated conversion function.
+func Convert_openstack_SubPort_To_v1alpha1_SubPort(in *openstack.SubPort, out *SubPort, s conversion.Scope) error {
+	return autoConvert_openstack_SubPort_To_v1alpha1_SubPort(in, out, s)
+}
+
+func autoConvert_v1alpha1_Trunk_To_openstack_Trunk(in *Trunk, out *openstack.Trunk, s conversion.Scope) error {
+	out.SubPorts = *(*[]openstack.SubPort)(unsafe.Pointer(&in.SubPorts))
+	return nil
+}
+
+// Convert_v1alpha1_Trunk_To_openstack_Trunk is an autogenerated conversion function.
+func Convert_v1alpha1_Trunk_To_openstack_Trunk(in *Trunk, out *openstack.Trunk, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Trunk_To_openstack_Trunk(in, out, s)
+}
+
+func autoConvert_openstack_Trunk_To_v1alpha1_Trunk(in *openstack.Trunk, out *Trunk, s conversion.Scope) error {
+	out.SubPorts = *(*[]SubPort)(unsafe.Pointer(&in.SubPorts))
+	return nil
+}
+
+// Convert_openstack_Trunk_To_v1alpha1_Trunk is an autogenerated conversion function.
+func Convert_openstack_Trunk_To_v1alpha1_Trunk(in *openstack.Trunk, out *Trunk, s conversion.Scope) error {
+	return autoConvert_openstack_Trunk_To_v1alpha1_Trunk(in, out, s)
+}
+
+func autoConvert_v1alpha1_UserDataOptions_To_openstack_UserDataOptions(in *UserDataOptions, out *openstack.UserDataOptions, s conversion.Scope) error {
+	out.Compress = (*bool)(unsafe.Pointer(in.Compress))
+	out.SwiftContainer = in.SwiftContainer
+	out.TempURLValidity = (*v1.Duration)(unsafe.Pointer(in.TempURLValidity))
+	return nil
+}
+
+// Convert_v1alpha1_UserDataOptions_To_openstack_UserDataOptions is an autogenerated conversion function.
+func Convert_v1alpha1_UserDataOptions_To_openstack_UserDataOptions(in *UserDataOptions, out *openstack.UserDataOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_UserDataOptions_To_openstack_UserDataOptions(in, out, s)
+}
+
+func autoConvert_openstack_UserDataOptions_To_v1alpha1_UserDataOptions(in *openstack.UserDataOptions, out *UserDataOptions, s conversion.Scope) error {
+	out.Compress = (*bool)(unsafe.Pointer(in.Compress))
+	out.SwiftContainer = in.SwiftContainer
+	out.TempURLValidity = (*v1.Duration)(unsafe.Pointer(in.TempURLValidity))
+	return nil
+}
+
+// Convert_openstack_UserDataOptions_To_v1alpha1_UserDataOptions is an autogenerated conversion function.
+func Convert_openstack_UserDataOptions_To_v1alpha1_UserDataOptions(in *openstack.UserDataOptions, out *UserDataOptions, s conversion.Scope) error {
+	return autoConvert_openstack_UserDataOptions_To_v1alpha1_UserDataOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_VolumeSchedulerHints_To_openstack_VolumeSchedulerHints(in *VolumeSchedulerHints, out *openstack.VolumeSchedulerHints, s conversion.Scope) error {
+	out.DifferentHost = *(*[]string)(unsafe.Pointer(&in.DifferentHost))
+	out.SameHost = *(*[]string)(unsafe.Pointer(&in.SameHost))
+	out.LocalToInstance = in.LocalToInstance
+	return nil
+}
+
+// Convert_v1alpha1_VolumeSchedulerHints_To_openstack_VolumeSchedulerHints is an autogenerated conversion function.
+func Convert_v1alpha1_VolumeSchedulerHints_To_openstack_VolumeSchedulerHints(in *VolumeSchedulerHints, out *openstack.VolumeSchedulerHints, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VolumeSchedulerHints_To_openstack_VolumeSchedulerHints(in, out, s)
+}
+
+func autoConvert_openstack_VolumeSchedulerHints_To_v1alpha1_VolumeSchedulerHints(in *openstack.VolumeSchedulerHints, out *VolumeSchedulerHints, s conversion.Scope) error {
+	out.DifferentHost = *(*[]string)(unsafe.Pointer(&in.DifferentHost))
+	out.SameHost = *(*[]string)(unsafe.Pointer(&in.SameHost))
+	out.LocalToInstance = in.LocalToInstance
+	return nil
+}
+
+// Convert_openstack_VolumeSchedulerHints_To_v1alpha1_VolumeSchedulerHints is an autogenerated conversion function.
+func Convert_openstack_VolumeSchedulerHints_To_v1alpha1_VolumeSchedulerHints(in *openstack.VolumeSchedulerHints, out *VolumeSchedulerHints, s conversion.Scope) error {
+	return autoConvert_openstack_VolumeSchedulerHints_To_v1alpha1_VolumeSchedulerHints(in, out, s)
+}
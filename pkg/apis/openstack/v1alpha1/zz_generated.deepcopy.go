@@ -10,9 +10,187 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedAddressPair) DeepCopyInto(out *AllowedAddressPair) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedAddressPair.
+func (in *AllowedAddressPair) DeepCopy() *AllowedAddressPair {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedAddressPair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BareMetal) DeepCopyInto(out *BareMetal) {
+	*out = *in
+	if in.DeployTimeout != nil {
+		in, out := &in.DeployTimeout, &out.DeployTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CleaningTimeout != nil {
+		in, out := &in.CleaningTimeout, &out.CleaningTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BareMetal.
+func (in *BareMetal) DeepCopy() *BareMetal {
+	if in == nil {
+		return nil
+	}
+	out := new(BareMetal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockDevice) DeepCopyInto(out *BlockDevice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockDevice.
+func (in *BlockDevice) DeepCopy() *BlockDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolume) DeepCopyInto(out *DataVolume) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.DeleteOnTermination != nil {
+		in, out := &in.DeleteOnTermination, &out.DeleteOnTermination
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolume.
+func (in *DataVolume) DeepCopy() *DataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FixedIP) DeepCopyInto(out *FixedIP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FixedIP.
+func (in *FixedIP) DeepCopy() *FixedIP {
+	if in == nil {
+		return nil
+	}
+	out := new(FixedIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorSelector) DeepCopyInto(out *FlavorSelector) {
+	*out = *in
+	if in.ExtraSpecs != nil {
+		in, out := &in.ExtraSpecs, &out.ExtraSpecs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorSelector.
+func (in *FlavorSelector) DeepCopy() *FlavorSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FloatingIP) DeepCopyInto(out *FloatingIP) {
+	*out = *in
+	if in.SubnetID != nil {
+		in, out := &in.SubnetID, &out.SubnetID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FloatingIP.
+func (in *FloatingIP) DeepCopy() *FloatingIP {
+	if in == nil {
+		return nil
+	}
+	out := new(FloatingIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSelector) DeepCopyInto(out *ImageSelector) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSelector.
+func (in *ImageSelector) DeepCopy() *ImageSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineProviderConfig) DeepCopyInto(out *MachineProviderConfig) {
 	*out = *in
@@ -42,6 +220,31 @@ func (in *MachineProviderConfig) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineProviderConfigSpec) DeepCopyInto(out *MachineProviderConfigSpec) {
 	*out = *in
+	if in.ImageSelector != nil {
+		in, out := &in.ImageSelector, &out.ImageSelector
+		*out = new(ImageSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedImageCertificates != nil {
+		in, out := &in.TrustedImageCertificates, &out.TrustedImageCertificates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FallbackAvailabilityZones != nil {
+		in, out := &in.FallbackAvailabilityZones, &out.FallbackAvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FlavorNames != nil {
+		in, out := &in.FlavorNames, &out.FlavorNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FlavorSelector != nil {
+		in, out := &in.FlavorSelector, &out.FlavorSelector
+		*out = new(FlavorSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecurityGroups != nil {
 		in, out := &in.SecurityGroups, &out.SecurityGroups
 		*out = make([]string, len(*in))
@@ -69,26 +272,92 @@ func (in *MachineProviderConfigSpec) DeepCopyInto(out *MachineProviderConfigSpec
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedAddressPairs != nil {
+		in, out := &in.AllowedAddressPairs, &out.AllowedAddressPairs
+		*out = make([]AllowedAddressPair, len(*in))
+		copy(*out, *in)
+	}
 	if in.RootDiskType != nil {
 		in, out := &in.RootDiskType, &out.RootDiskType
 		*out = new(string)
 		**out = **in
 	}
+	if in.RootVolumeSource != nil {
+		in, out := &in.RootVolumeSource, &out.RootVolumeSource
+		*out = new(RootVolumeSource)
+		**out = **in
+	}
 	if in.UseConfigDrive != nil {
 		in, out := &in.UseConfigDrive, &out.UseConfigDrive
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UserDataOptions != nil {
+		in, out := &in.UserDataOptions, &out.UserDataOptions
+		*out = new(UserDataOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServerGroupID != nil {
 		in, out := &in.ServerGroupID, &out.ServerGroupID
 		*out = new(string)
 		**out = **in
 	}
+	if in.ServerGroup != nil {
+		in, out := &in.ServerGroup, &out.ServerGroup
+		*out = new(ServerGroup)
+		**out = **in
+	}
+	if in.SchedulerHints != nil {
+		in, out := &in.SchedulerHints, &out.SchedulerHints
+		*out = new(SchedulerHints)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Networks != nil {
 		in, out := &in.Networks, &out.Networks
 		*out = make([]OpenStackNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BareMetal != nil {
+		in, out := &in.BareMetal, &out.BareMetal
+		*out = new(BareMetal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockDevices != nil {
+		in, out := &in.BlockDevices, &out.BlockDevices
+		*out = make([]BlockDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.VolumeSchedulerHints != nil {
+		in, out := &in.VolumeSchedulerHints, &out.VolumeSchedulerHints
+		*out = new(VolumeSchedulerHints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeMetadata != nil {
+		in, out := &in.VolumeMetadata, &out.VolumeMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FloatingIP != nil {
+		in, out := &in.FloatingIP, &out.FloatingIP
+		*out = new(FloatingIP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trunk != nil {
+		in, out := &in.Trunk, &out.Trunk
+		*out = new(Trunk)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -105,6 +374,33 @@ func (in *MachineProviderConfigSpec) DeepCopy() *MachineProviderConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackNetwork) DeepCopyInto(out *OpenStackNetwork) {
 	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FixedIPs != nil {
+		in, out := &in.FixedIPs, &out.FixedIPs
+		*out = make([]FixedIP, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedAddressPairs != nil {
+		in, out := &in.AllowedAddressPairs, &out.AllowedAddressPairs
+		*out = make([]AllowedAddressPair, len(*in))
+		copy(*out, *in)
+	}
+	if in.BindingProfile != nil {
+		in, out := &in.BindingProfile, &out.BindingProfile
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -117,3 +413,155 @@ func (in *OpenStackNetwork) DeepCopy() *OpenStackNetwork {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootVolumeSource) DeepCopyInto(out *RootVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootVolumeSource.
+func (in *RootVolumeSource) DeepCopy() *RootVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RootVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerHints) DeepCopyInto(out *SchedulerHints) {
+	*out = *in
+	if in.DifferentHost != nil {
+		in, out := &in.DifferentHost, &out.DifferentHost
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SameHost != nil {
+		in, out := &in.SameHost, &out.SameHost
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DifferentCell != nil {
+		in, out := &in.DifferentCell, &out.DifferentCell
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerHints.
+func (in *SchedulerHints) DeepCopy() *SchedulerHints {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerHints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGroup) DeepCopyInto(out *ServerGroup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroup.
+func (in *ServerGroup) DeepCopy() *ServerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubPort) DeepCopyInto(out *SubPort) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubPort.
+func (in *SubPort) DeepCopy() *SubPort {
+	if in == nil {
+		return nil
+	}
+	out := new(SubPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trunk) DeepCopyInto(out *Trunk) {
+	*out = *in
+	if in.SubPorts != nil {
+		in, out := &in.SubPorts, &out.SubPorts
+		*out = make([]SubPort, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trunk.
+func (in *Trunk) DeepCopy() *Trunk {
+	if in == nil {
+		return nil
+	}
+	out := new(Trunk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataOptions) DeepCopyInto(out *UserDataOptions) {
+	*out = *in
+	if in.Compress != nil {
+		in, out := &in.Compress, &out.Compress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TempURLValidity != nil {
+		in, out := &in.TempURLValidity, &out.TempURLValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataOptions.
+func (in *UserDataOptions) DeepCopy() *UserDataOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSchedulerHints) DeepCopyInto(out *VolumeSchedulerHints) {
+	*out = *in
+	if in.DifferentHost != nil {
+		in, out := &in.DifferentHost, &out.DifferentHost
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SameHost != nil {
+		in, out := &in.SameHost, &out.SameHost
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSchedulerHints.
+func (in *VolumeSchedulerHints) DeepCopy() *VolumeSchedulerHints {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSchedulerHints)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -6,16 +6,40 @@
 package validation
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	. "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
 )
 
+// supportedServerGroupPolicies are the policies of server groups managed by MCM.
+var supportedServerGroupPolicies = sets.New("affinity", "anti-affinity", "soft-affinity", "soft-anti-affinity")
+
+// supportedRootVolumeDeletionPolicies are the policies applied to the root volume when a machine is deleted.
+var supportedRootVolumeDeletionPolicies = sets.New(RootVolumeDeletionPolicyDelete, RootVolumeDeletionPolicyRetain, RootVolumeDeletionPolicyBackup)
+
+// supportedBlockDeviceTypes are the types of local disks of the instance.
+var supportedBlockDeviceTypes = sets.New(BlockDeviceTypeEphemeral, BlockDeviceTypeSwap)
+
+// uuidRegex matches the IDs of OpenStack resources.
+var uuidRegex = regexp.MustCompile("^[a-z0-9]{8}-[a-z0-9]{4}-[1-5][a-z0-9]{3}-[a-z0-9]{4}-[a-z0-9]{12}$")
+
+// supportedVNICTypes are the vNIC types that can be requested for ports of a network.
+var supportedVNICTypes = sets.New(VNICTypeNormal, VNICTypeDirect, VNICTypeDirectPhysical, VNICTypeMacvtap, VNICTypeVirtioForwarder, VNICTypeVDPA)
+
+// supportedImageVisibilities are the image visibilities an image selector can match.
+var supportedImageVisibilities = sets.New("public", "private", "shared", "community")
+
 // ValidateRequest validates a request received by the OpenStack driver.
 func ValidateRequest(providerConfig *openstack.MachineProviderConfig, secret *corev1.Secret) error {
 	allErrs := field.ErrorList{}
@@ -33,21 +57,30 @@ func validateMachineProviderConfig(providerConfig *openstack.MachineProviderConf
 
 	fldPath := field.NewPath("spec")
 
-	if providerConfig.Spec.ImageID == "" {
+	if providerConfig.Spec.ImageID == "" && providerConfig.Spec.ImageSelector == nil && providerConfig.Spec.RootVolumeSource == nil {
 		if providerConfig.Spec.ImageName == "" {
-			allErrs = append(allErrs, field.Required(fldPath.Child("imageName"), "ImageName is required if no ImageID is given"))
+			allErrs = append(allErrs, field.Required(fldPath.Child("imageName"), "ImageName is required if no ImageID, ImageSelector or RootVolumeSource is given"))
 		}
 	}
 
 	if providerConfig.Spec.Region == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("region"), "Region is required"))
 	}
-	if providerConfig.Spec.FlavorName == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("flavorName"), "Flavor is required"))
+	if providerConfig.Spec.FlavorName == "" && providerConfig.Spec.FlavorSelector == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("flavorName"), "Flavor is required if no FlavorSelector is given"))
 	}
 	if providerConfig.Spec.AvailabilityZone == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("availabilityZone"), "AvailabilityZone name is required"))
 	}
+	zones := sets.New(providerConfig.Spec.AvailabilityZone)
+	for index, zone := range providerConfig.Spec.FallbackAvailabilityZones {
+		if zone == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("fallbackAvailabilityZones").Index(index), "availability zone must not be empty"))
+		} else if zones.Has(zone) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("fallbackAvailabilityZones").Index(index), zone))
+		}
+		zones.Insert(zone)
+	}
 	if providerConfig.Spec.KeyName == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("keyName"), "KeyName is required"))
 	}
@@ -60,18 +93,63 @@ func validateMachineProviderConfig(providerConfig *openstack.MachineProviderConf
 	if len(providerConfig.Spec.PodNetworkCIDRs) == 0 && len(providerConfig.Spec.PodNetworkCidr) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("PodNetworkCIDRs"), "PodNetworkCIDRs is required"))
 	}
+	if cidr := providerConfig.Spec.PodNetworkCidr; cidr != "" {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("podNetworkCidr"), cidr, "must be a valid IPv4 or IPv6 CIDR"))
+		}
+	}
+	for i, cidr := range providerConfig.Spec.PodNetworkCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("podNetworkCIDRs").Index(i), cidr, "must be a valid IPv4 or IPv6 CIDR"))
+		}
+	}
+	allErrs = append(allErrs, validateAllowedAddressPairs(providerConfig.Spec.AllowedAddressPairs, fldPath.Child("allowedAddressPairs"))...)
 	if providerConfig.Spec.RootDiskSize < 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("rootDiskSize"), "RootDiskSize can not be negative"))
 	}
+	if options := providerConfig.Spec.UserDataOptions; options != nil && options.TempURLValidity != nil && options.TempURLValidity.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("userDataOptions", "tempURLValidity"), options.TempURLValidity.Duration.String(), "must be positive"))
+	}
+	allErrs = append(allErrs, validateServerTemplates(&providerConfig.Spec, fldPath)...)
+	if len(providerConfig.Spec.TrustedImageCertificates) > 0 && (providerConfig.Spec.RootDiskSize > 0 || providerConfig.Spec.RootVolumeSource != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("trustedImageCertificates"), "trusted image certificates can not be used when booting from volume"))
+	}
+	if bareMetal := providerConfig.Spec.BareMetal; bareMetal != nil {
+		if bareMetal.DeployTimeout != nil && bareMetal.DeployTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("bareMetal", "deployTimeout"), bareMetal.DeployTimeout.Duration.String(), "must be positive"))
+		}
+		if bareMetal.CleaningTimeout != nil && bareMetal.CleaningTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("bareMetal", "cleaningTimeout"), bareMetal.CleaningTimeout.Duration.String(), "must be positive"))
+		}
+	}
+	if policy := providerConfig.Spec.RootVolumeDeletionPolicy; policy != "" {
+		if !supportedRootVolumeDeletionPolicies.Has(policy) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("rootVolumeDeletionPolicy"), policy, sets.List(supportedRootVolumeDeletionPolicies)))
+		} else if policy != RootVolumeDeletionPolicyDelete && providerConfig.Spec.RootDiskSize == 0 && providerConfig.Spec.RootVolumeSource == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("rootVolumeDeletionPolicy"), "a deletion policy other than \"Delete\" requires a root volume, i.e. \"rootDiskSize\" or \"rootVolumeSource\""))
+		}
+	}
 
-	allErrs = append(allErrs, validateNetworks(providerConfig.Spec.Networks, providerConfig.Spec.PodNetworkCidr, providerConfig.Spec.PodNetworkCIDRs, field.NewPath("spec.networks"))...)
+	allErrs = append(allErrs, validateImageSelector(&providerConfig.Spec, fldPath.Child("imageSelector"))...)
+	allErrs = append(allErrs, validateRootVolumeSource(&providerConfig.Spec, fldPath.Child("rootVolumeSource"))...)
+	allErrs = append(allErrs, validateFlavors(&providerConfig.Spec, fldPath)...)
+	allErrs = append(allErrs, validateNetworks(providerConfig.Spec.Networks, providerConfig.Spec.ManagedPorts, providerConfig.Spec.PodNetworkCidr, providerConfig.Spec.PodNetworkCIDRs, field.NewPath("spec.networks"))...)
 	allErrs = append(allErrs, validateClassSpecTags(providerConfig.Spec.Tags, field.NewPath("spec.tags"))...)
+	allErrs = append(allErrs, validateDataVolumes(providerConfig.Spec.DataVolumes, field.NewPath("spec.dataVolumes"))...)
+	allErrs = append(allErrs, validateBlockDevices(providerConfig.Spec.BlockDevices, field.NewPath("spec.blockDevices"))...)
+	allErrs = append(allErrs, validateFloatingIP(providerConfig.Spec.FloatingIP, field.NewPath("spec.floatingIP"))...)
+	allErrs = append(allErrs, validateServerGroup(&providerConfig.Spec, field.NewPath("spec.serverGroup"))...)
+	allErrs = append(allErrs, validateSchedulerHints(providerConfig.Spec.SchedulerHints, field.NewPath("spec.schedulerHints"))...)
+	allErrs = append(allErrs, validateVolumeSchedulerHints(providerConfig.Spec.VolumeSchedulerHints, field.NewPath("spec.volumeSchedulerHints"))...)
+	allErrs = append(allErrs, validateTrunk(&providerConfig.Spec, field.NewPath("spec.trunk"))...)
 
 	return allErrs
 }
 
-func validateNetworks(networks []openstack.OpenStackNetwork, podNetworkCidr string, podNetworkCIDRs []string, fldPath *field.Path) field.ErrorList {
+func validateNetworks(networks []openstack.OpenStackNetwork, managedPorts bool, podNetworkCidr string, podNetworkCIDRs []string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	portNameSuffixes := sets.New[string]()
+	hasPrimary := false
 
 	for index, network := range networks {
 		fldPath := fldPath.Index(index)
@@ -84,6 +162,379 @@ func validateNetworks(networks []openstack.OpenStackNetwork, podNetworkCidr stri
 		if len(podNetworkCIDRs) == 0 && len(podNetworkCidr) == 0 && network.PodNetwork {
 			allErrs = append(allErrs, field.Required(fldPath.Child("podNetwork"), "\"podNetwork\" switch should not be used in absence of \"spec.podNetworkCidr\""))
 		}
+		if !managedPorts {
+			allErrs = append(allErrs, validateUnmanagedPort(network, fldPath)...)
+		}
+		if network.DisablePortSecurity && len(network.SecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("securityGroups"), "\"securityGroups\" can not be used if \"disablePortSecurity\" is set"))
+		}
+		if network.DisablePortSecurity && len(network.AllowedAddressPairs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("allowedAddressPairs"), "\"allowedAddressPairs\" can not be used if \"disablePortSecurity\" is set"))
+		}
+		allErrs = append(allErrs, validateAllowedAddressPairs(network.AllowedAddressPairs, fldPath.Child("allowedAddressPairs"))...)
+		if network.VNICType != "" && !supportedVNICTypes.Has(network.VNICType) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("vnicType"), network.VNICType, sets.List(supportedVNICTypes)))
+		}
+		if network.VNICType == VNICTypeDirectPhysical && len(network.SecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("securityGroups"), fmt.Sprintf("\"securityGroups\" can not be used for ports with vNIC type %q", network.VNICType)))
+		}
+		for key := range network.BindingProfile {
+			if key == "" {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("bindingProfile"), key, "binding profile keys must not be empty"))
+			}
+		}
+		for i, fixedIP := range network.FixedIPs {
+			if fixedIP.SubnetID == "" && fixedIP.IPAddress == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("fixedIPs").Index(i), "at least one of fixed IP \"subnetID\" or \"ipAddress\" is required"))
+			}
+			if fixedIP.IPAddress != "" && net.ParseIP(fixedIP.IPAddress) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("fixedIPs").Index(i).Child("ipAddress"), fixedIP.IPAddress, "must be a valid IP address"))
+			}
+		}
+
+		if network.Primary {
+			if hasPrimary {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("primary"), "only one network can be primary"))
+			}
+			hasPrimary = true
+		}
+
+		portNameSuffix := network.PortNameSuffix
+		if portNameSuffix == "" {
+			portNameSuffix = strconv.Itoa(index)
+		}
+		if portNameSuffixes.Has(portNameSuffix) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("portNameSuffix"), portNameSuffix))
+		}
+		portNameSuffixes.Insert(portNameSuffix)
+	}
+
+	return allErrs
+}
+
+// validateUnmanagedPort rejects the port options of a network whose port is created by Nova.
+func validateUnmanagedPort(network openstack.OpenStackNetwork, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	portOptions := map[string]bool{
+		"subnetIDs":           len(network.SubnetIDs) > 0,
+		"fixedIPs":            len(network.FixedIPs) > 0,
+		"securityGroups":      len(network.SecurityGroups) > 0,
+		"portNameSuffix":      network.PortNameSuffix != "",
+		"disablePortSecurity": network.DisablePortSecurity,
+		"allowedAddressPairs": len(network.AllowedAddressPairs) > 0,
+		"vnicType":            network.VNICType != "",
+		"bindingProfile":      len(network.BindingProfile) > 0,
+	}
+	for _, option := range sets.List(sets.KeySet(portOptions)) {
+		if portOptions[option] {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(option), fmt.Sprintf("%q requires \"spec.managedPorts\"", option)))
+		}
+	}
+	return allErrs
+}
+
+func validateAllowedAddressPairs(pairs []openstack.AllowedAddressPair, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, pair := range pairs {
+		fldPath := fldPath.Index(i)
+		if pair.IPAddress == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("ipAddress"), "ipAddress is required"))
+		} else if _, _, err := net.ParseCIDR(pair.IPAddress); err != nil && net.ParseIP(pair.IPAddress) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ipAddress"), pair.IPAddress, "must be a valid IP address or CIDR"))
+		}
+		if pair.MACAddress != "" {
+			if _, err := net.ParseMAC(pair.MACAddress); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("macAddress"), pair.MACAddress, "must be a valid MAC address"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateImageSelector(spec *openstack.MachineProviderConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	selector := spec.ImageSelector
+	if selector == nil {
+		return allErrs
+	}
+
+	if spec.ImageID != "" || spec.ImageName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "\"imageSelector\" can not be used along with \"imageID\" or \"imageName\""))
+	}
+	if selector.Name == "" && len(selector.Properties) == 0 && len(selector.Tags) == 0 && selector.Owner == "" && selector.Visibility == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one image selection criterion is required"))
+	}
+	if selector.Visibility != "" && !supportedImageVisibilities.Has(selector.Visibility) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("visibility"), selector.Visibility, sets.List(supportedImageVisibilities)))
+	}
+
+	return allErrs
+}
+
+func validateServerTemplates(spec *openstack.MachineProviderConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Hostname != "" && spec.FQDN != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("fqdn"), "can not be combined with \"hostname\""))
+	}
+
+	templates := []struct {
+		name string
+		text string
+	}{
+		{name: "hostname", text: spec.Hostname},
+		{name: "fqdn", text: spec.FQDN},
+		{name: "description", text: spec.Description},
+	}
+	for _, t := range templates {
+		if _, err := template.New(t.name).Parse(t.text); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(t.name), t.text, fmt.Sprintf("invalid template: %v", err)))
+		}
+	}
+	return allErrs
+}
+
+func validateRootVolumeSource(spec *openstack.MachineProviderConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	source := spec.RootVolumeSource
+	if source == nil {
+		return allErrs
+	}
+
+	if spec.ImageID != "" || spec.ImageName != "" || spec.ImageSelector != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "\"rootVolumeSource\" can not be used along with \"imageID\", \"imageName\" or \"imageSelector\""))
+	}
+	sources := 0
+	for _, value := range []string{source.SnapshotID, source.SnapshotName, source.VolumeID, source.VolumeName} {
+		if value != "" {
+			sources++
+		}
+	}
+	switch {
+	case sources == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "one of \"snapshotID\", \"snapshotName\", \"volumeID\" or \"volumeName\" is required"))
+	case sources > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of \"snapshotID\", \"snapshotName\", \"volumeID\" or \"volumeName\" can be specified"))
+	}
+
+	return allErrs
+}
+
+func validateFlavors(spec *openstack.MachineProviderConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	flavorNames := sets.New(spec.FlavorName)
+	for index, flavorName := range spec.FlavorNames {
+		if flavorName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("flavorNames").Index(index), "flavor name must not be empty"))
+		} else if flavorNames.Has(flavorName) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("flavorNames").Index(index), flavorName))
+		}
+		flavorNames.Insert(flavorName)
+	}
+
+	selector := spec.FlavorSelector
+	if selector == nil {
+		return allErrs
+	}
+
+	fldPath = fldPath.Child("flavorSelector")
+	if spec.FlavorName != "" || len(spec.FlavorNames) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "\"flavorSelector\" can not be used along with \"flavorName\" or \"flavorNames\""))
+	}
+	if selector.MinVCPUs < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minVCPUs"), selector.MinVCPUs, "can not be negative"))
+	}
+	if selector.MinRAM < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minRAM"), selector.MinRAM, "can not be negative"))
+	}
+	if selector.MinDisk < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minDisk"), selector.MinDisk, "can not be negative"))
+	}
+	if selector.MinVCPUs == 0 && selector.MinRAM == 0 && selector.MinDisk == 0 && len(selector.ExtraSpecs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one flavor requirement is required"))
+	}
+
+	return allErrs
+}
+
+func validateDataVolumes(dataVolumes []openstack.DataVolume, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.New[string]()
+
+	for index, dataVolume := range dataVolumes {
+		fldPath := fldPath.Index(index)
+		if dataVolume.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "data volume \"name\" is required"))
+		} else if names.Has(dataVolume.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), dataVolume.Name))
+		}
+		names.Insert(dataVolume.Name)
+		if dataVolume.Size <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), dataVolume.Size, "data volume \"size\" must be positive"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateBlockDevices(blockDevices []openstack.BlockDevice, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	deviceNames := sets.New[string]()
+	swap := false
+
+	for index, blockDevice := range blockDevices {
+		fldPath := fldPath.Index(index)
+		if !supportedBlockDeviceTypes.Has(blockDevice.Type) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), blockDevice.Type, sets.List(supportedBlockDeviceTypes)))
+		}
+		if blockDevice.Type == BlockDeviceTypeSwap {
+			if swap {
+				allErrs = append(allErrs, field.Forbidden(fldPath, "only one swap disk can be specified"))
+			}
+			if blockDevice.GuestFormat != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("guestFormat"), "swap disks can not be formatted"))
+			}
+			swap = true
+		}
+		if blockDevice.Size < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), blockDevice.Size, "block device \"size\" can not be negative"))
+		}
+		if blockDevice.DeviceName != "" {
+			if deviceNames.Has(blockDevice.DeviceName) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("deviceName"), blockDevice.DeviceName))
+			}
+			deviceNames.Insert(blockDevice.DeviceName)
+		}
+	}
+
+	return allErrs
+}
+
+func validateFloatingIP(floatingIP *openstack.FloatingIP, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if floatingIP == nil {
+		return allErrs
+	}
+
+	if floatingIP.NetworkID == "" && floatingIP.NetworkName == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of floating IP \"networkID\" or \"networkName\" is required"))
+	}
+	if floatingIP.NetworkID != "" && floatingIP.NetworkName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "simultaneous use of floating IP \"networkID\" and \"networkName\" is forbidden"))
+	}
+	for index, address := range floatingIP.Pool {
+		if net.ParseIP(address) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pool").Index(index), address, "must be a valid IP address"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateServerGroup(spec *openstack.MachineProviderConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	serverGroup := spec.ServerGroup
+	if serverGroup == nil {
+		return allErrs
+	}
+
+	if spec.ServerGroupID != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "\"serverGroup\" can not be used along with \"serverGroupID\""))
+	}
+	if serverGroup.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "server group name is required"))
+	}
+	if !supportedServerGroupPolicies.Has(serverGroup.Policy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("policy"), serverGroup.Policy, sets.List(supportedServerGroupPolicies)))
+	}
+
+	return allErrs
+}
+
+func validateSchedulerHints(hints *openstack.SchedulerHints, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hints == nil {
+		return allErrs
+	}
+
+	for index, serverID := range hints.DifferentHost {
+		if !uuidRegex.MatchString(serverID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("differentHost").Index(index), serverID, "must be a server ID"))
+		}
+	}
+	for index, serverID := range hints.SameHost {
+		if !uuidRegex.MatchString(serverID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sameHost").Index(index), serverID, "must be a server ID"))
+		}
+	}
+	if hints.Query != "" {
+		var query []any
+		if err := json.Unmarshal([]byte(hints.Query), &query); err != nil || len(query) < 3 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("query"), hints.Query, "must be a JSON encoded conditional statement in the format of [op, variable, value]"))
+		}
+	}
+	if hints.BuildNearHostIP != "" {
+		if _, _, err := net.ParseCIDR(hints.BuildNearHostIP); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("buildNearHostIP"), hints.BuildNearHostIP, "must be a valid subnet in CIDR notation"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateVolumeSchedulerHints(hints *openstack.VolumeSchedulerHints, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hints == nil {
+		return allErrs
+	}
+
+	for index, volumeID := range hints.DifferentHost {
+		if !uuidRegex.MatchString(volumeID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("differentHost").Index(index), volumeID, "must be a volume ID"))
+		}
+	}
+	for index, volumeID := range hints.SameHost {
+		if !uuidRegex.MatchString(volumeID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sameHost").Index(index), volumeID, "must be a volume ID"))
+		}
+	}
+	if hints.LocalToInstance != "" && !uuidRegex.MatchString(hints.LocalToInstance) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("localToInstance"), hints.LocalToInstance, "must be a server ID"))
+	}
+
+	return allErrs
+}
+
+func validateTrunk(spec *openstack.MachineProviderConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Trunk == nil {
+		return allErrs
+	}
+
+	// the parent port of the trunk has to be created by MCM before the server
+	hasSubnets := (spec.SubnetID != nil && *spec.SubnetID != "") || len(spec.SubnetIDs) > 0
+	hasManagedPort := (len(spec.Networks) > 0 && spec.ManagedPorts) || (spec.NetworkID != "" && hasSubnets)
+	if !hasManagedPort {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a trunk requires \"networks\" together with \"managedPorts\" or \"networkID\" together with \"subnetID\" or \"subnetIDs\""))
+	}
+
+	segmentationIDs := sets.New[int]()
+	for index, subPort := range spec.Trunk.SubPorts {
+		fldPath := fldPath.Child("subPorts").Index(index)
+		if subPort.NetworkID == "" && subPort.NetworkName == "" {
+			allErrs = append(allErrs, field.Required(fldPath, "at least one of subport \"networkID\" or \"networkName\" is required"))
+		}
+		if subPort.NetworkID != "" && subPort.NetworkName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "simultaneous use of subport \"networkID\" and \"networkName\" is forbidden"))
+		}
+		if subPort.SegmentationID < 1 || subPort.SegmentationID > 4094 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("segmentationID"), subPort.SegmentationID, "must be a VLAN ID between 1 and 4094"))
+		} else if segmentationIDs.Has(subPort.SegmentationID) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("segmentationID"), subPort.SegmentationID))
+		}
+		segmentationIDs.Insert(subPort.SegmentationID)
 	}
 
 	return allErrs
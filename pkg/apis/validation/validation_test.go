@@ -6,11 +6,14 @@ package validation
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	. "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
 	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
@@ -87,6 +90,145 @@ var _ = Describe("Validation", func() {
 			})
 		})
 
+		Context("#ImageSelector", func() {
+			It("should allow an image selector instead of an image ID or name", func() {
+				spec := &machineProviderConfig.Spec
+				spec.ImageID = ""
+				spec.ImageName = ""
+				spec.ImageSelector = &api.ImageSelector{Tags: []string{"release"}}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(BeEmpty())
+			})
+
+			It("should fail if the image selector is incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.ImageSelector = &api.ImageSelector{Visibility: "foo"}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.imageSelector"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueNotSupported"),
+						"Field": Equal("spec.imageSelector.visibility"),
+					})),
+				))
+			})
+		})
+
+		Context("#FallbackAvailabilityZones", func() {
+			It("should fail if the fallback availability zones are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.FallbackAvailabilityZones = []string{spec.AvailabilityZone, ""}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueDuplicate"),
+						"Field": Equal("spec.fallbackAvailabilityZones[0]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueRequired"),
+						"Field": Equal("spec.fallbackAvailabilityZones[1]"),
+					})),
+				))
+			})
+		})
+
+		Context("#RootVolumeSource", func() {
+			It("should not require an image if a root volume source is given", func() {
+				spec := &machineProviderConfig.Spec
+				spec.ImageID = ""
+				spec.ImageName = ""
+				spec.RootVolumeSource = &api.RootVolumeSource{SnapshotName: "golden"}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(BeEmpty())
+			})
+
+			It("should fail if the root volume source is incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.RootVolumeSource = &api.RootVolumeSource{SnapshotID: "foo", VolumeName: "bar"}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   BeEquivalentTo("FieldValueForbidden"),
+						"Field":  Equal("spec.rootVolumeSource"),
+						"Detail": ContainSubstring("imageName"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   BeEquivalentTo("FieldValueForbidden"),
+						"Field":  Equal("spec.rootVolumeSource"),
+						"Detail": ContainSubstring("only one"),
+					})),
+				))
+			})
+		})
+
+		Context("#RootVolumeDeletionPolicy", func() {
+			It("should fail if the root volume deletion policy is not supported", func() {
+				machineProviderConfig.Spec.RootDiskSize = 50
+				machineProviderConfig.Spec.RootVolumeDeletionPolicy = "Keep"
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  BeEquivalentTo("FieldValueNotSupported"),
+					"Field": Equal("spec.rootVolumeDeletionPolicy"),
+				}))))
+			})
+
+			It("should fail if the root volume deletion policy is given without root volume", func() {
+				machineProviderConfig.Spec.RootVolumeDeletionPolicy = RootVolumeDeletionPolicyRetain
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  BeEquivalentTo("FieldValueForbidden"),
+					"Field": Equal("spec.rootVolumeDeletionPolicy"),
+				}))))
+			})
+		})
+
+		Context("#Flavors", func() {
+			It("should allow a flavor selector instead of a flavor name", func() {
+				spec := &machineProviderConfig.Spec
+				spec.FlavorName = ""
+				spec.FlavorSelector = &api.FlavorSelector{MinVCPUs: 4}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(BeEmpty())
+			})
+
+			It("should fail if the flavors are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.FlavorNames = []string{"fallback", "", "fallback"}
+				spec.FlavorSelector = &api.FlavorSelector{MinRAM: -1}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueRequired"),
+						"Field": Equal("spec.flavorNames[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueDuplicate"),
+						"Field": Equal("spec.flavorNames[2]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.flavorSelector"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.flavorSelector.minRAM"),
+					})),
+				))
+			})
+		})
+
 		Context("#Networks", func() {
 			It("should not allow Networks and NetworkID data in the same request", func() {
 				spec := &machineProviderConfig.Spec
@@ -140,6 +282,442 @@ var _ = Describe("Validation", func() {
 			})
 		})
 
+		Context("#Networks ports", func() {
+			It("should fail if the port definitions are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.NetworkID = ""
+				spec.ManagedPorts = true
+				spec.Networks = []api.OpenStackNetwork{
+					{
+						Id:                  "foo",
+						SecurityGroups:      []string{"sg"},
+						DisablePortSecurity: true,
+						FixedIPs:            []api.FixedIP{{}, {IPAddress: "foo"}},
+					},
+					{
+						Id:             "bar",
+						PortNameSuffix: "0",
+					},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.networks[0].securityGroups"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueRequired"),
+						"Field": Equal("spec.networks[0].fixedIPs[0]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.networks[0].fixedIPs[1].ipAddress"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueDuplicate"),
+						"Field": Equal("spec.networks[1].portNameSuffix"),
+					})),
+				))
+			})
+
+			It("should fail if the allowed address pairs are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.AllowedAddressPairs = []api.AllowedAddressPair{
+					{IPAddress: "10.250.0.100"},
+					{IPAddress: "foo"},
+				}
+				spec.NetworkID = ""
+				spec.ManagedPorts = true
+				spec.Networks = []api.OpenStackNetwork{
+					{
+						Id:                  "foo",
+						DisablePortSecurity: true,
+						AllowedAddressPairs: []api.AllowedAddressPair{{IPAddress: "10.250.0.0/24", MACAddress: "bar"}},
+					},
+					{
+						Id:                  "bar",
+						AllowedAddressPairs: []api.AllowedAddressPair{{MACAddress: "fa:16:3e:00:00:01"}},
+					},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.allowedAddressPairs[1].ipAddress"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.networks[0].allowedAddressPairs"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.networks[0].allowedAddressPairs[0].macAddress"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueRequired"),
+						"Field": Equal("spec.networks[1].allowedAddressPairs[0].ipAddress"),
+					})),
+				))
+			})
+
+			It("should fail if port options are used without managed ports", func() {
+				spec := &machineProviderConfig.Spec
+				spec.NetworkID = ""
+				spec.Networks = []api.OpenStackNetwork{
+					{Id: "foo", PodNetwork: true},
+					{Id: "bar", SubnetIDs: []string{"subnet"}, VNICType: "direct"},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.networks[1].subnetIDs"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.networks[1].vnicType"),
+					})),
+				))
+			})
+
+			It("should fail if more than one network is primary", func() {
+				spec := &machineProviderConfig.Spec
+				spec.NetworkID = ""
+				spec.Networks = []api.OpenStackNetwork{
+					{Id: "foo", Primary: true},
+					{Id: "bar", Primary: true},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.networks[1].primary"),
+					})),
+				))
+			})
+
+			It("should fail if the port binding options are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.NetworkID = ""
+				spec.ManagedPorts = true
+				spec.Networks = []api.OpenStackNetwork{
+					{
+						Id:       "foo",
+						VNICType: "foo",
+					},
+					{
+						Id:             "bar",
+						VNICType:       "direct-physical",
+						SecurityGroups: []string{"sg"},
+						BindingProfile: map[string]string{"": "bar"},
+					},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueNotSupported"),
+						"Field": Equal("spec.networks[0].vnicType"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.networks[1].securityGroups"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.networks[1].bindingProfile"),
+					})),
+				))
+			})
+		})
+
+		Context("#DataVolumes", func() {
+			It("should fail if data volumes are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.DataVolumes = []api.DataVolume{
+					{Name: "data", Size: 10},
+					{Name: "data", Size: 10},
+					{Name: "", Size: 0},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueDuplicate"),
+						"Field": Equal("spec.dataVolumes[1].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueRequired"),
+						"Field": Equal("spec.dataVolumes[2].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.dataVolumes[2].size"),
+					})),
+				))
+			})
+		})
+
+		Context("#BlockDevices", func() {
+			It("should fail if the block devices are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.BlockDevices = []api.BlockDevice{
+					{Type: "ephemeral", GuestFormat: "ext4", DeviceName: "/dev/vdb"},
+					{Type: "swap", Size: 1024, GuestFormat: "ext4", DeviceName: "/dev/vdb"},
+					{Type: "swap", Size: -1},
+					{Type: "volume"},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.blockDevices[1].guestFormat"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueDuplicate"),
+						"Field": Equal("spec.blockDevices[1].deviceName"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.blockDevices[2]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.blockDevices[2].size"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueNotSupported"),
+						"Field": Equal("spec.blockDevices[3].type"),
+					})),
+				))
+			})
+		})
+
+		Context("#UserDataOptions", func() {
+			It("should fail if the temp URL validity is not positive", func() {
+				machineProviderConfig.Spec.UserDataOptions = &api.UserDataOptions{
+					SwiftContainer:  "userdata",
+					TempURLValidity: &metav1.Duration{},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  BeEquivalentTo("FieldValueInvalid"),
+					"Field": Equal("spec.userDataOptions.tempURLValidity"),
+				}))))
+			})
+		})
+
+		Context("#ServerTemplates", func() {
+			It("should fail if the templates are invalid or hostname and FQDN are combined", func() {
+				machineProviderConfig.Spec.Hostname = "{{ .MachineName }"
+				machineProviderConfig.Spec.FQDN = "{{ .MachineName }}.example.com"
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.fqdn"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.hostname"),
+					})),
+				))
+			})
+		})
+
+		Context("#TrustedImageCertificates", func() {
+			It("should fail if the server boots from volume", func() {
+				machineProviderConfig.Spec.TrustedImageCertificates = []string{"certID"}
+				machineProviderConfig.Spec.RootDiskSize = 50
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  BeEquivalentTo("FieldValueForbidden"),
+					"Field": Equal("spec.trustedImageCertificates"),
+				}))))
+			})
+		})
+
+		Context("#PodNetworkCIDRs", func() {
+			It("should accept IPv4 and IPv6 pod network CIDRs", func() {
+				machineProviderConfig.Spec.PodNetworkCIDRs = []string{"100.96.0.0/11", "fd00:10:96::/48"}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(BeEmpty())
+			})
+
+			It("should fail if a pod network CIDR is invalid", func() {
+				machineProviderConfig.Spec.PodNetworkCIDRs = []string{"100.96.0.0/11", "fd00:10:96::"}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  BeEquivalentTo("FieldValueInvalid"),
+					"Field": Equal("spec.podNetworkCIDRs[1]"),
+				}))))
+			})
+		})
+
+		Context("#BareMetal", func() {
+			It("should fail if the timeouts are not positive", func() {
+				machineProviderConfig.Spec.BareMetal = &api.BareMetal{
+					DeployTimeout:   &metav1.Duration{},
+					CleaningTimeout: &metav1.Duration{Duration: -time.Minute},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.bareMetal.deployTimeout"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.bareMetal.cleaningTimeout"),
+					})),
+				))
+			})
+		})
+
+		Context("#ServerGroup", func() {
+			It("should fail if the server group is incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.ServerGroupID = ptr.To("6f1c5b3a-8d2e-4c7f-9a1b-2e3d4c5b6a7f")
+				spec.ServerGroup = &api.ServerGroup{Policy: "spread"}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.serverGroup"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueRequired"),
+						"Field": Equal("spec.serverGroup.name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueNotSupported"),
+						"Field": Equal("spec.serverGroup.policy"),
+					})),
+				))
+			})
+		})
+
+		Context("#SchedulerHints", func() {
+			It("should fail if the scheduler hints are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.SchedulerHints = &api.SchedulerHints{
+					DifferentHost:   []string{"foo"},
+					SameHost:        []string{"0b4e8e7c-3f1a-4d2b-8c9d-1a2b3c4d5e6f"},
+					Query:           `[">=", "$free_ram_mb"]`,
+					BuildNearHostIP: "192.168.1.1",
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.schedulerHints.differentHost[0]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.schedulerHints.query"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.schedulerHints.buildNearHostIP"),
+					})),
+				))
+			})
+		})
+
+		Context("#VolumeSchedulerHints", func() {
+			It("should fail if the volume scheduler hints are incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.VolumeSchedulerHints = &api.VolumeSchedulerHints{
+					DifferentHost:   []string{"0b4e8e7c-3f1a-4d2b-8c9d-1a2b3c4d5e6f"},
+					SameHost:        []string{"foo"},
+					LocalToInstance: "bar",
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.volumeSchedulerHints.sameHost[0]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.volumeSchedulerHints.localToInstance"),
+					})),
+				))
+			})
+		})
+
+		Context("#Trunk", func() {
+			It("should fail if the trunk is incorrect", func() {
+				spec := &machineProviderConfig.Spec
+				spec.Trunk = &api.Trunk{SubPorts: []api.SubPort{
+					{NetworkID: "foo", SegmentationID: 100},
+					{NetworkName: "bar", SegmentationID: 100},
+					{NetworkID: "foo", NetworkName: "bar", SegmentationID: 4095},
+				}}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.trunk"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueDuplicate"),
+						"Field": Equal("spec.trunk.subPorts[1].segmentationID"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.trunk.subPorts[2]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.trunk.subPorts[2].segmentationID"),
+					})),
+				))
+			})
+		})
+
+		Context("#FloatingIP", func() {
+			It("should allow a floating IP network ID", func() {
+				machineProviderConfig.Spec.FloatingIP = &api.FloatingIP{NetworkID: "ext"}
+				Expect(validateMachineProviderConfig(machineProviderConfig)).To(BeEmpty())
+			})
+
+			It("should fail if the floating IP is incorrect", func() {
+				machineProviderConfig.Spec.FloatingIP = &api.FloatingIP{
+					NetworkID:   "ext",
+					NetworkName: "ext",
+					Pool:        []string{"1.2.3.4", "foo"},
+				}
+
+				err := validateMachineProviderConfig(machineProviderConfig)
+				Expect(err).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueForbidden"),
+						"Field": Equal("spec.floatingIP"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  BeEquivalentTo("FieldValueInvalid"),
+						"Field": Equal("spec.floatingIP.pool[1]"),
+					})),
+				))
+			})
+		})
+
 		Context("#Tags", func() {
 			It("should return an error if the cluster tags are missing", func() {
 				spec := &machineProviderConfig.Spec
@@ -10,6 +10,8 @@ import (
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/backups"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/snapshots"
 	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
 )
 
@@ -25,8 +27,18 @@ const (
 	VolumeStatusDeleting = "deleting"
 	// VolumeStatusError indicates that the volume is in error state.
 	VolumeStatusError = "error"
+	// VolumeStatusErrorDeleting indicates that the deletion of the volume failed.
+	VolumeStatusErrorDeleting = "error_deleting"
 	// VolumeStatusInUse indicates that the volume is currently in use.
 	VolumeStatusInUse = "in-use"
+	// VolumeStatusDetaching indicates that the volume is being detached from a server.
+	VolumeStatusDetaching = "detaching"
+	// VolumeStatusBackingUp indicates that a backup of the volume is being created.
+	VolumeStatusBackingUp = "backing-up"
+	// BackupStatusAvailable indicates that the backup is ready to be used.
+	BackupStatusAvailable = "available"
+	// BackupStatusCreating indicates that the backup is being created.
+	BackupStatusCreating = "creating"
 )
 
 var _ Storage = &cinderV3{}
@@ -109,3 +121,62 @@ func (c *cinderV3) ListVolumes(ctx context.Context, opts volumes.ListOptsBuilder
 
 	return volumes.ExtractVolumes(vols)
 }
+
+// SnapshotIDFromName resolves the given snapshot name to a unique ID.
+func (c *cinderV3) SnapshotIDFromName(ctx context.Context, name string) (string, error) {
+	listOpts := snapshots.ListOpts{
+		Name: name,
+	}
+
+	listFunc := func(ctx context.Context) ([]snapshots.Snapshot, error) {
+		allPages, err := snapshots.List(c.serviceClient, listOpts).AllPages(ctx)
+		onCall(cinderService)
+		if err != nil {
+			onFailure(cinderService)
+			return nil, err
+		}
+		return snapshots.ExtractSnapshots(allPages)
+	}
+
+	getNameFunc := func(snapshot snapshots.Snapshot) string {
+		return snapshot.Name
+	}
+
+	snapshot, err := findSingleByName(ctx, listFunc, getNameFunc, name, "snapshot")
+
+	return snapshot.ID, err
+}
+
+// CreateBackup creates a backup of a volume.
+func (c *cinderV3) CreateBackup(ctx context.Context, opts backups.CreateOptsBuilder) (*backups.Backup, error) {
+	b, err := backups.Create(ctx, c.serviceClient, opts).Extract()
+	onCall(cinderService)
+	if err != nil {
+		onFailure(cinderService)
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetBackup retrieves information about a backup.
+func (c *cinderV3) GetBackup(ctx context.Context, id string) (*backups.Backup, error) {
+	b, err := backups.Get(ctx, c.serviceClient, id).Extract()
+	onCall(cinderService)
+	if err != nil {
+		onFailure(cinderService)
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListBackups lists all backups
+func (c *cinderV3) ListBackups(ctx context.Context, opts backups.ListOptsBuilder) ([]backups.Backup, error) {
+	pages, err := backups.List(c.serviceClient, opts).AllPages(ctx)
+	onCall(cinderService)
+	if err != nil {
+		onFailure(cinderService)
+		return nil, err
+	}
+
+	return backups.ExtractBackups(pages)
+}
@@ -45,3 +45,13 @@ func IsForbidden(err error) bool {
 
 	return gophercloud.ResponseCodeIs(err, http.StatusForbidden)
 }
+
+// IsPreconditionFailed checks if an error returned by OpenStack service calls is caused by HTTP 412 status code, e.g.
+// because a resource has been modified since it was read.
+func IsPreconditionFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return gophercloud.ResponseCodeIs(err, http.StatusPreconditionFailed)
+}
@@ -177,6 +177,16 @@ func (f *Factory) Network(opts ...Option) (Network, error) {
 	return newNeutronV2(f.providerClient, eo)
 }
 
+// Image returns a client for OpenStack's Glance service.
+func (f *Factory) Image(opts ...Option) (Image, error) {
+	eo := gophercloud.EndpointOpts{}
+	for _, opt := range opts {
+		eo = opt(eo)
+	}
+
+	return newGlanceV2(f.providerClient, eo)
+}
+
 // Storage returns a client for OpenStack's Cinder service.
 func (f *Factory) Storage(opts ...Option) (Storage, error) {
 	eo := gophercloud.EndpointOpts{}
@@ -186,3 +196,23 @@ func (f *Factory) Storage(opts ...Option) (Storage, error) {
 
 	return newCinderV3(f.providerClient, eo)
 }
+
+// ObjectStorage returns a client for OpenStack's Swift service.
+func (f *Factory) ObjectStorage(opts ...Option) (ObjectStorage, error) {
+	eo := gophercloud.EndpointOpts{}
+	for _, opt := range opts {
+		eo = opt(eo)
+	}
+
+	return newSwiftV1(f.providerClient, eo)
+}
+
+// Baremetal returns a client for OpenStack's Ironic service.
+func (f *Factory) Baremetal(opts ...Option) (Baremetal, error) {
+	eo := gophercloud.EndpointOpts{}
+	for _, opt := range opts {
+		eo = opt(eo)
+	}
+
+	return newIronicV1(f.providerClient, eo)
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+)
+
+const glanceService = "glance"
+
+var _ Image = &glanceV2{}
+
+// glanceV2 is a GlanceV2 client implementing the Image interface.
+type glanceV2 struct {
+	serviceClient *gophercloud.ServiceClient
+}
+
+func newGlanceV2(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*glanceV2, error) {
+	image, err := openstack.NewImageV2(providerClient, eo)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize image client: %v", err)
+	}
+
+	return &glanceV2{
+		serviceClient: image,
+	}, nil
+}
+
+// ListImages lists all images matching the supplied options.
+func (g *glanceV2) ListImages(ctx context.Context, opts images.ListOptsBuilder) ([]images.Image, error) {
+	pages, err := images.List(g.serviceClient, opts).AllPages(ctx)
+	onCall(glanceService)
+
+	if err != nil {
+		onFailure(glanceService)
+		return nil, err
+	}
+
+	return images.ExtractImages(pages)
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+)
+
+const ironicService = "ironic"
+
+var _ Baremetal = &ironicV1{}
+
+// ironicV1 is an IronicV1 client implementing the Baremetal interface.
+type ironicV1 struct {
+	serviceClient *gophercloud.ServiceClient
+}
+
+func newIronicV1(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*ironicV1, error) {
+	baremetal, err := openstack.NewBareMetalV1(providerClient, eo)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize baremetal client: %v", err)
+	}
+
+	return &ironicV1{
+		serviceClient: baremetal,
+	}, nil
+}
+
+// GetNode retrieves information about a node.
+func (i *ironicV1) GetNode(ctx context.Context, id string) (*nodes.Node, error) {
+	node, err := nodes.Get(ctx, i.serviceClient, id).Extract()
+	onCall(ironicService)
+	if err != nil {
+		onFailure(ironicService)
+		return nil, err
+	}
+	return node, nil
+}
+
+// ListNodes lists the nodes matching the supplied options.
+func (i *ironicV1) ListNodes(ctx context.Context, opts nodes.ListOptsBuilder) ([]nodes.Node, error) {
+	pages, err := nodes.ListDetail(i.serviceClient, opts).AllPages(ctx)
+	onCall(ironicService)
+	if err != nil {
+		onFailure(ironicService)
+		return nil, err
+	}
+	return nodes.ExtractNodes(pages)
+}
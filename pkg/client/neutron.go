@@ -11,10 +11,14 @@ import (
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/attributestags"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/provider"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/trunks"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
+	"k8s.io/utils/ptr"
 )
 
 var _ Network = &neutronV2{}
@@ -46,6 +50,36 @@ func (n *neutronV2) GetSubnet(ctx context.Context, id string) (*subnets.Subnet,
 	return sn, nil
 }
 
+// GetNetworkProviderAttributes fetches the provider attributes of the network with the supplied ID. The provider attributes
+// are usually only visible to administrators and empty otherwise.
+func (n *neutronV2) GetNetworkProviderAttributes(ctx context.Context, id string) (*provider.NetworkProviderExt, error) {
+	var attributes provider.NetworkProviderExt
+	err := networks.Get(ctx, n.serviceClient, id).ExtractInto(&attributes)
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return nil, err
+	}
+	return &attributes, nil
+}
+
+// GetNetworkPortSecurity fetches whether port security is enabled on the network with the supplied ID. Port security is
+// assumed to be enabled if the port security extension is not available.
+func (n *neutronV2) GetNetworkPortSecurity(ctx context.Context, id string) (bool, error) {
+	var network struct {
+		PortSecurityEnabled *bool `json:"port_security_enabled"`
+	}
+	err := networks.Get(ctx, n.serviceClient, id).ExtractInto(&network)
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return false, err
+	}
+	return ptr.Deref(network.PortSecurityEnabled, true), nil
+}
+
 // CreatePort creates a Neutron port.
 func (n *neutronV2) CreatePort(ctx context.Context, opts ports.CreateOptsBuilder) (*ports.Port, error) {
 	p, err := ports.Create(ctx, n.serviceClient, opts).Extract()
@@ -71,6 +105,24 @@ func (n *neutronV2) ListPorts(ctx context.Context, opts ports.ListOptsBuilder) (
 	return ports.ExtractPorts(pages)
 }
 
+// ListPortsWithDNS lists all ports including the attributes of the DNS integration, which are empty if the DNS
+// integration is not enabled.
+func (n *neutronV2) ListPortsWithDNS(ctx context.Context, opts ports.ListOptsBuilder) ([]PortWithDNS, error) {
+	pages, err := ports.List(n.serviceClient, opts).AllPages(ctx)
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return nil, err
+	}
+
+	var portList []PortWithDNS
+	if err := ports.ExtractPortsInto(pages, &portList); err != nil {
+		return nil, err
+	}
+	return portList, nil
+}
+
 // UpdatePort updates the port from the supplied ID.
 func (n *neutronV2) UpdatePort(ctx context.Context, id string, opts ports.UpdateOptsBuilder) error {
 	_, err := ports.Update(ctx, n.serviceClient, id, opts).Extract()
@@ -98,6 +150,119 @@ func (n *neutronV2) DeletePort(ctx context.Context, id string) error {
 	return nil
 }
 
+// CreateFloatingIP allocates a floating IP.
+func (n *neutronV2) CreateFloatingIP(ctx context.Context, opts floatingips.CreateOptsBuilder) (*floatingips.FloatingIP, error) {
+	fip, err := floatingips.Create(ctx, n.serviceClient, opts).Extract()
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return nil, err
+	}
+	return fip, nil
+}
+
+// ListFloatingIPs lists all floating IPs.
+func (n *neutronV2) ListFloatingIPs(ctx context.Context, opts floatingips.ListOptsBuilder) ([]floatingips.FloatingIP, error) {
+	pages, err := floatingips.List(n.serviceClient, opts).AllPages(ctx)
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return nil, err
+	}
+
+	return floatingips.ExtractFloatingIPs(pages)
+}
+
+// UpdateFloatingIP updates the floating IP from the supplied ID.
+func (n *neutronV2) UpdateFloatingIP(ctx context.Context, id string, opts floatingips.UpdateOptsBuilder) error {
+	_, err := floatingips.Update(ctx, n.serviceClient, id, opts).Extract()
+	onCall("neutron")
+
+	if err != nil {
+		// skip registering not found errors as API errors
+		if !IsNotFoundError(err) {
+			onFailure("neutron")
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteFloatingIP releases the floating IP from the supplied ID. If the floating IP does not exist it returns nil.
+func (n *neutronV2) DeleteFloatingIP(ctx context.Context, id string) error {
+	err := floatingips.Delete(ctx, n.serviceClient, id).ExtractErr()
+
+	onCall("neutron")
+	if err != nil && !IsNotFoundError(err) {
+		onFailure("neutron")
+		return err
+	}
+	return nil
+}
+
+// TagFloatingIP replaces the tags of the floating IP from the supplied ID.
+func (n *neutronV2) TagFloatingIP(ctx context.Context, id string, tags []string) error {
+	tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
+	_, err := attributestags.ReplaceAll(ctx, n.serviceClient, "floatingips", id, tagOpts).Extract()
+	onCall("neutron")
+	if err != nil {
+		onFailure("neutron")
+		return err
+	}
+	return nil
+}
+
+// CreateTrunk creates a Neutron trunk.
+func (n *neutronV2) CreateTrunk(ctx context.Context, opts trunks.CreateOptsBuilder) (*trunks.Trunk, error) {
+	trunk, err := trunks.Create(ctx, n.serviceClient, opts).Extract()
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return nil, err
+	}
+	return trunk, nil
+}
+
+// ListTrunks lists all trunks.
+func (n *neutronV2) ListTrunks(ctx context.Context, opts trunks.ListOptsBuilder) ([]trunks.Trunk, error) {
+	pages, err := trunks.List(n.serviceClient, opts).AllPages(ctx)
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return nil, err
+	}
+
+	return trunks.ExtractTrunks(pages)
+}
+
+// AddSubports adds subports to the trunk from the supplied ID.
+func (n *neutronV2) AddSubports(ctx context.Context, id string, opts trunks.AddSubportsOptsBuilder) error {
+	_, err := trunks.AddSubports(ctx, n.serviceClient, id, opts).Extract()
+	onCall("neutron")
+
+	if err != nil {
+		onFailure("neutron")
+		return err
+	}
+	return nil
+}
+
+// DeleteTrunk deletes the trunk from the supplied ID. If the trunk does not exist it returns nil.
+func (n *neutronV2) DeleteTrunk(ctx context.Context, id string) error {
+	err := trunks.Delete(ctx, n.serviceClient, id).ExtractErr()
+
+	onCall("neutron")
+	if err != nil && !IsNotFoundError(err) {
+		onFailure("neutron")
+		return err
+	}
+	return nil
+}
+
 // NetworkIDFromName resolves the given network name to a unique ID.
 func (n *neutronV2) NetworkIDFromName(ctx context.Context, name string) (string, error) {
 	listOpts := networks.ListOpts{
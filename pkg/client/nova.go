@@ -5,14 +5,21 @@
 package client
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack"
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/tags"
 	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	"github.com/gophercloud/gophercloud/v2/openstack/utils"
+	"k8s.io/klog/v2"
 )
 
 const (
@@ -26,13 +33,86 @@ const (
 	ServerStatusDeleted = "DELETED"
 	// ServerStatusError indicates that the server is in error.
 	ServerStatusError = "ERROR"
+	// ServerStatusShutoff indicates that the server was powered down.
+	ServerStatusShutoff = "SHUTOFF"
+	// ServerStatusSoftDeleted indicates that the server is marked as deleted but will remain in the cloud for some configurable amount of time.
+	ServerStatusSoftDeleted = "SOFT_DELETED"
 )
 
+const (
+	// MicroversionServerTags is the compute API microversion which introduced server tags and the filtering of servers
+	// by tags.
+	MicroversionServerTags = "2.26"
+	// MicroversionServerCreateTags is the compute API microversion which allows to set server tags on server creation.
+	MicroversionServerCreateTags = "2.52"
+	// MicroversionSoftServerGroupPolicies is the compute API microversion which introduced the soft-affinity and
+	// soft-anti-affinity server group policies.
+	MicroversionSoftServerGroupPolicies = "2.15"
+	// MicroversionServerDescription is the compute API microversion which introduced server descriptions.
+	MicroversionServerDescription = "2.19"
+	// MicroversionTrustedImageCertificates is the compute API microversion which introduced trusted image certificates.
+	MicroversionTrustedImageCertificates = "2.63"
+	// MicroversionServerGroupPolicy is the compute API microversion which replaced the list of server group policies by
+	// a single policy.
+	MicroversionServerGroupPolicy = "2.64"
+	// MicroversionHostPinning is the compute API microversion which allows to request a host or hypervisor on server
+	// creation.
+	MicroversionHostPinning = "2.74"
+	// MicroversionServerHostname is the compute API microversion which allows to set the hostname of a server
+	// independently of its name.
+	MicroversionServerHostname = "2.90"
+	// MicroversionServerFQDN is the compute API microversion which allows fully qualified domain names as hostname of a
+	// server.
+	MicroversionServerFQDN = "2.94"
+
+	// MaxMicroversion is the highest compute API microversion supported by the client. Requests are only sent with a
+	// microversion if they use a feature that requires it, otherwise the base version is used.
+	MaxMicroversion = MicroversionServerFQDN
+)
+
+// ComputeCapabilities are the optional features of the compute API supported by the cloud.
+type ComputeCapabilities struct {
+	// ServerTags is true if servers can be tagged and filtered by their tags.
+	ServerTags bool
+	// ServerCreateTags is true if server tags can be set on server creation.
+	ServerCreateTags bool
+	// Description is true if servers can have a description.
+	Description bool
+	// TrustedImageCertificates is true if trusted image certificates can be set on server creation.
+	TrustedImageCertificates bool
+	// HostPinning is true if a host or hypervisor can be requested on server creation.
+	HostPinning bool
+	// Hostname is true if the hostname of a server can be set independently of its name.
+	Hostname bool
+	// FQDN is true if the hostname of a server can be a fully qualified domain name.
+	FQDN bool
+}
+
+// GetComputeCapabilities returns the optional features of the compute API supported by the cloud.
+func GetComputeCapabilities(ctx context.Context, compute Compute) ComputeCapabilities {
+	return ComputeCapabilities{
+		ServerTags:               compute.SupportsMicroversion(ctx, MicroversionServerTags),
+		ServerCreateTags:         compute.SupportsMicroversion(ctx, MicroversionServerCreateTags),
+		Description:              compute.SupportsMicroversion(ctx, MicroversionServerDescription),
+		TrustedImageCertificates: compute.SupportsMicroversion(ctx, MicroversionTrustedImageCertificates),
+		HostPinning:              compute.SupportsMicroversion(ctx, MicroversionHostPinning),
+		Hostname:                 compute.SupportsMicroversion(ctx, MicroversionServerHostname),
+		FQDN:                     compute.SupportsMicroversion(ctx, MicroversionServerFQDN),
+	}
+}
+
 var _ Compute = &novaV2{}
 
 // novaV2 is a NovaV2 client implementing the Compute interface.
 type novaV2 struct {
 	serviceClient *gophercloud.ServiceClient
+
+	microversionsOnce sync.Once
+	microversions     utils.SupportedMicroversions
+	microversionsErr  error
+	// microversion is the highest microversion supported by both the cloud and the client. It is empty if the cloud
+	// supports the base version only.
+	microversion string
 }
 
 func newNovaV2(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*novaV2, error) {
@@ -46,9 +126,13 @@ func newNovaV2(providerClient *gophercloud.ProviderClient, eo gophercloud.Endpoi
 	}, nil
 }
 
-// CreateServer creates a server.
+// CreateServer creates a server. The request is sent with the lowest microversion supporting all fields of the server.
 func (c *novaV2) CreateServer(ctx context.Context, opts servers.CreateOptsBuilder, hintOpts servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
-	server, err := servers.Create(ctx, c.serviceClient, opts, hintOpts).Extract()
+	version, err := createServerMicroversion(opts)
+	if err != nil {
+		return nil, err
+	}
+	server, err := servers.Create(ctx, c.client(ctx, version), opts, hintOpts).Extract()
 	onCall("nova")
 	if err != nil {
 		onFailure("nova")
@@ -57,9 +141,9 @@ func (c *novaV2) CreateServer(ctx context.Context, opts servers.CreateOptsBuilde
 	return server, nil
 }
 
-// GetServer fetches server data from the supplied ID.
+// GetServer fetches server data from the supplied ID. The server tags are included if supported by the cloud.
 func (c *novaV2) GetServer(ctx context.Context, id string) (*servers.Server, error) {
-	server, err := servers.Get(ctx, c.serviceClient, id).Extract()
+	server, err := servers.Get(ctx, c.client(ctx, MicroversionServerTags), id).Extract()
 
 	onCall("nova")
 	if err != nil {
@@ -71,9 +155,10 @@ func (c *novaV2) GetServer(ctx context.Context, id string) (*servers.Server, err
 	return server, nil
 }
 
-// ListServers lists all servers based on opts constraints.
+// ListServers lists all servers based on opts constraints. The server tags are included and can be filtered on if
+// supported by the cloud.
 func (c *novaV2) ListServers(ctx context.Context, opts servers.ListOptsBuilder) ([]servers.Server, error) {
-	pages, err := servers.List(c.serviceClient, opts).AllPages(ctx)
+	pages, err := servers.List(c.client(ctx, MicroversionServerTags), opts).AllPages(ctx)
 
 	onCall("nova")
 	if err != nil {
@@ -95,6 +180,156 @@ func (c *novaV2) DeleteServer(ctx context.Context, id string) error {
 	return nil
 }
 
+// CreateServerGroup creates a server group. A single policy is passed as policy instead of as list of policies if the
+// cloud supports it, soft policies are requested with the microversion that introduced them.
+func (c *novaV2) CreateServerGroup(ctx context.Context, opts servergroups.CreateOptsBuilder) (*servergroups.ServerGroup, error) {
+	var version string
+	if createOpts, ok := opts.(servergroups.CreateOpts); ok && len(createOpts.Policies) == 1 {
+		switch {
+		case c.SupportsMicroversion(ctx, MicroversionServerGroupPolicy):
+			version = MicroversionServerGroupPolicy
+			createOpts.Policy, createOpts.Policies = createOpts.Policies[0], nil
+			opts = createOpts
+		case strings.HasPrefix(createOpts.Policies[0], "soft-"):
+			version = MicroversionSoftServerGroupPolicies
+		}
+	}
+	serverGroup, err := servergroups.Create(ctx, c.client(ctx, version), opts).Extract()
+	onCall("nova")
+	if err != nil {
+		onFailure("nova")
+		return nil, err
+	}
+	return serverGroup, nil
+}
+
+// ListServerGroups lists all server groups of the project.
+func (c *novaV2) ListServerGroups(ctx context.Context) ([]servergroups.ServerGroup, error) {
+	allPages, err := servergroups.List(c.serviceClient, nil).AllPages(ctx)
+	onCall("nova")
+	if err != nil {
+		onFailure("nova")
+		return nil, err
+	}
+	return servergroups.ExtractServerGroups(allPages)
+}
+
+// DeleteServerGroup deletes the server group from the supplied ID. If the server group does not exist it returns nil.
+func (c *novaV2) DeleteServerGroup(ctx context.Context, id string) error {
+	err := servergroups.Delete(ctx, c.serviceClient, id).ExtractErr()
+
+	onCall("nova")
+	if err != nil && !IsNotFoundError(err) {
+		onFailure("nova")
+		return err
+	}
+	return nil
+}
+
+// SupportsMicroversion returns true if both the compute API and the client support the given microversion. Clouds whose
+// supported microversions cannot be discovered are treated as supporting the base version only.
+func (c *novaV2) SupportsMicroversion(ctx context.Context, version string) bool {
+	c.negotiateMicroversion(ctx)
+	if c.microversion == "" {
+		return false
+	}
+
+	supported, err := c.microversions.IsSupported(version)
+	if err != nil {
+		klog.Warningf("failed to check support of compute API microversion %s: %v", version, err)
+		return false
+	}
+	return supported && compareMicroversions(version, c.microversion) <= 0
+}
+
+// negotiateMicroversion discovers the microversions supported by the compute API once and records the highest one also
+// supported by the client.
+func (c *novaV2) negotiateMicroversion(ctx context.Context) {
+	c.microversionsOnce.Do(func() {
+		c.microversions, c.microversionsErr = utils.GetSupportedMicroversions(ctx, c.serviceClient)
+		onCall("nova")
+		if c.microversionsErr != nil {
+			klog.Warningf("failed to discover the supported compute API microversions: %v", c.microversionsErr)
+			return
+		}
+		if c.microversions.MaxMajor == 0 {
+			return
+		}
+
+		c.microversion = MaxMicroversion
+		if maxVersion := fmt.Sprintf("%d.%d", c.microversions.MaxMajor, c.microversions.MaxMinor); compareMicroversions(maxVersion, MaxMicroversion) < 0 {
+			c.microversion = maxVersion
+		}
+		klog.V(3).Infof("negotiated compute API microversion %s", c.microversion)
+	})
+}
+
+// compareMicroversions compares the microversions a and b and returns -1, 0 or +1 if a is lower, equal to or higher
+// than b. Microversions which can not be parsed are treated as 0.0.
+func compareMicroversions(a, b string) int {
+	var aMajor, aMinor, bMajor, bMinor int
+	_, _ = fmt.Sscanf(a, "%d.%d", &aMajor, &aMinor)
+	_, _ = fmt.Sscanf(b, "%d.%d", &bMajor, &bMinor)
+	return cmp.Or(cmp.Compare(aMajor, bMajor), cmp.Compare(aMinor, bMinor))
+}
+
+// ReplaceServerTags replaces all tags of the server with the supplied ID.
+func (c *novaV2) ReplaceServerTags(ctx context.Context, id string, serverTags []string) error {
+	_, err := tags.ReplaceAll(ctx, c.client(ctx, MicroversionServerTags), id, tags.ReplaceAllOpts{Tags: serverTags}).Extract()
+	onCall("nova")
+	if err != nil {
+		onFailure("nova")
+		return err
+	}
+	return nil
+}
+
+// client returns the service client to use for a request that requires the given microversion. The base version is
+// used if no microversion is given or the cloud does not support it.
+func (c *novaV2) client(ctx context.Context, version string) *gophercloud.ServiceClient {
+	if version == "" || !c.SupportsMicroversion(ctx, version) {
+		return c.serviceClient
+	}
+
+	sc := *c.serviceClient
+	sc.Microversion = version
+	return &sc
+}
+
+// createServerMicroversions are the microversions required by the optional fields of a server create request.
+var createServerMicroversions = map[string]string{
+	"description":                MicroversionServerDescription,
+	"tags":                       MicroversionServerCreateTags,
+	"trusted_image_certificates": MicroversionTrustedImageCertificates,
+	"host":                       MicroversionHostPinning,
+	"hypervisor_hostname":        MicroversionHostPinning,
+	"hostname":                   MicroversionServerHostname,
+}
+
+// createServerMicroversion returns the lowest microversion which supports all fields of the server create request, or
+// an empty string if the base version is sufficient.
+func createServerMicroversion(opts servers.CreateOptsBuilder) (string, error) {
+	body, err := opts.ToServerCreateMap()
+	if err != nil {
+		return "", err
+	}
+	server, _ := body["server"].(map[string]any)
+
+	var version string
+	for field, fieldVersion := range createServerMicroversions {
+		if _, ok := server[field]; !ok {
+			continue
+		}
+		if hostname, ok := server[field].(string); ok && field == "hostname" && strings.Contains(hostname, ".") {
+			fieldVersion = MicroversionServerFQDN
+		}
+		if compareMicroversions(fieldVersion, version) > 0 {
+			version = fieldVersion
+		}
+	}
+	return version, nil
+}
+
 // ImageIDFromName resolves the given image name to a unique ID.
 func (c *novaV2) ImageIDFromName(ctx context.Context, name string) (images.Image, error) {
 	listOpts := images.ListOpts{
@@ -118,6 +353,39 @@ func (c *novaV2) ImageIDFromName(ctx context.Context, name string) (images.Image
 	return findSingleByName(ctx, listFunc, getNameFunc, name, "image")
 }
 
+// ListFlavors lists all flavors accessible by the user.
+func (c *novaV2) ListFlavors(ctx context.Context) ([]flavors.Flavor, error) {
+	allPages, err := flavors.ListDetail(c.serviceClient, nil).AllPages(ctx)
+	onCall("nova")
+	if err != nil {
+		onFailure("nova")
+		return nil, err
+	}
+	return flavors.ExtractFlavors(allPages)
+}
+
+// GetFlavor retrieves the flavor with the supplied ID.
+func (c *novaV2) GetFlavor(ctx context.Context, id string) (*flavors.Flavor, error) {
+	flavor, err := flavors.Get(ctx, c.serviceClient, id).Extract()
+	onCall("nova")
+	if err != nil {
+		onFailure("nova")
+		return nil, err
+	}
+	return flavor, nil
+}
+
+// ListFlavorExtraSpecs lists the extra specs of the flavor with the supplied ID.
+func (c *novaV2) ListFlavorExtraSpecs(ctx context.Context, id string) (map[string]string, error) {
+	extraSpecs, err := flavors.ListExtraSpecs(ctx, c.serviceClient, id).Extract()
+	onCall("nova")
+	if err != nil {
+		onFailure("nova")
+		return nil, err
+	}
+	return extraSpecs, nil
+}
+
 // FlavorIDFromName resolves the given flavor name to a unique ID.
 func (c *novaV2) FlavorIDFromName(ctx context.Context, name string) (string, error) {
 	listFunc := func(ctx context.Context) ([]flavors.Flavor, error) {
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nova", func() {
+	var (
+		ctx           context.Context
+		server        *httptest.Server
+		maxVersion    string
+		discoveries   int
+		microversions map[string]string
+		nova          *novaV2
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		maxVersion = "2.96"
+		discoveries = 0
+		microversions = map[string]string{}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/v2.1/" && maxVersion == "":
+				discoveries++
+				w.WriteHeader(http.StatusInternalServerError)
+			case r.URL.Path == "/v2.1/":
+				discoveries++
+				_, _ = fmt.Fprintf(w, `{"version": {"id": "v2.1", "status": "CURRENT", "version": %q, "min_version": "2.1"}}`, maxVersion)
+			default:
+				microversions[r.Method+" "+r.URL.Path] = r.Header.Get("X-OpenStack-Nova-API-Version")
+				switch r.URL.Path {
+				case "/v2.1/servers":
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = fmt.Fprint(w, `{"server": {"id": "id"}}`)
+				case "/v2.1/servers/detail":
+					_, _ = fmt.Fprint(w, `{"servers": []}`)
+				case "/v2.1/servers/id":
+					_, _ = fmt.Fprint(w, `{"server": {"id": "id"}}`)
+				case "/v2.1/os-server-groups":
+					_, _ = fmt.Fprint(w, `{"server_group": {"id": "id"}}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}
+		}))
+
+		nova = &novaV2{
+			serviceClient: &gophercloud.ServiceClient{
+				ProviderClient: &gophercloud.ProviderClient{},
+				Endpoint:       server.URL + "/v2.1/",
+				Type:           "compute",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#negotiateMicroversion", func() {
+		It("should cap the microversion at the highest one supported by the client", func() {
+			nova.negotiateMicroversion(ctx)
+			Expect(nova.microversion).To(Equal(MaxMicroversion))
+		})
+
+		It("should use the highest microversion of the cloud if it is lower", func() {
+			maxVersion = "2.60"
+			nova.negotiateMicroversion(ctx)
+			Expect(nova.microversion).To(Equal("2.60"))
+		})
+
+		It("should fall back to the base version if the discovery fails", func() {
+			maxVersion = ""
+			nova.negotiateMicroversion(ctx)
+			Expect(nova.microversionsErr).To(HaveOccurred())
+			Expect(nova.microversion).To(BeEmpty())
+			Expect(nova.SupportsMicroversion(ctx, MicroversionServerTags)).To(BeFalse())
+		})
+
+		It("should discover the microversions only once", func() {
+			nova.negotiateMicroversion(ctx)
+			nova.negotiateMicroversion(ctx)
+			Expect(discoveries).To(Equal(1))
+		})
+	})
+
+	Describe("#GetComputeCapabilities", func() {
+		It("should report all features if the cloud supports the highest microversion of the client", func() {
+			Expect(GetComputeCapabilities(ctx, nova)).To(Equal(ComputeCapabilities{
+				ServerTags:               true,
+				ServerCreateTags:         true,
+				Description:              true,
+				TrustedImageCertificates: true,
+				HostPinning:              true,
+				Hostname:                 true,
+				FQDN:                     true,
+			}))
+		})
+
+		It("should report the features up to the highest microversion of the cloud", func() {
+			maxVersion = "2.60"
+			Expect(GetComputeCapabilities(ctx, nova)).To(Equal(ComputeCapabilities{
+				ServerTags:       true,
+				ServerCreateTags: true,
+				Description:      true,
+			}))
+		})
+
+		It("should report no features if the microversions can not be discovered", func() {
+			maxVersion = ""
+			Expect(GetComputeCapabilities(ctx, nova)).To(Equal(ComputeCapabilities{}))
+		})
+	})
+
+	Describe("#client", func() {
+		It("should only raise the microversion for requests using a feature", func() {
+			_, err := nova.CreateServer(ctx, servers.CreateOpts{Name: "foo", FlavorRef: "flavor"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = nova.CreateServerGroup(ctx, servergroups.CreateOpts{Name: "foo", Policies: []string{"anti-affinity"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(microversions).To(Equal(map[string]string{
+				"POST /v2.1/servers":          "",
+				"POST /v2.1/os-server-groups": MicroversionServerGroupPolicy,
+			}))
+		})
+
+		It("should raise the microversion to the one required by the fields of the server", func() {
+			_, err := nova.CreateServer(ctx, servers.CreateOpts{Name: "foo", FlavorRef: "flavor", Tags: []string{"tag"}, Hostname: "foo"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(microversions).To(HaveKeyWithValue("POST /v2.1/servers", MicroversionServerHostname))
+
+			_, err = nova.CreateServer(ctx, servers.CreateOpts{Name: "foo", FlavorRef: "flavor", Hostname: "foo.example.com"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(microversions).To(HaveKeyWithValue("POST /v2.1/servers", MicroversionServerFQDN))
+		})
+
+		It("should read servers with the microversion including their tags", func() {
+			_, err := nova.GetServer(ctx, "id")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = nova.ListServers(ctx, servers.ListOpts{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(microversions).To(Equal(map[string]string{
+				"GET /v2.1/servers/id":     MicroversionServerTags,
+				"GET /v2.1/servers/detail": MicroversionServerTags,
+			}))
+		})
+
+		It("should request soft server group policies with the microversion introducing them", func() {
+			maxVersion = "2.60"
+			_, err := nova.CreateServerGroup(ctx, servergroups.CreateOpts{Name: "foo", Policies: []string{"soft-anti-affinity"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(microversions).To(HaveKeyWithValue("POST /v2.1/os-server-groups", MicroversionSoftServerGroupPolicies))
+		})
+
+		It("should send all requests with the base version if the microversions can not be discovered", func() {
+			maxVersion = ""
+			_, err := nova.CreateServer(ctx, servers.CreateOpts{Name: "foo", FlavorRef: "flavor", Tags: []string{"tag"}}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = nova.GetServer(ctx, "id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(microversions).To(Equal(map[string]string{
+				"POST /v2.1/servers":   "",
+				"GET /v2.1/servers/id": "",
+			}))
+		})
+	})
+
+	DescribeTable("#compareMicroversions",
+		func(a, b string, expected int) {
+			Expect(compareMicroversions(a, b)).To(Equal(expected))
+		},
+		Entry("equal microversions", "2.90", "2.90", 0),
+		Entry("lower minor version", "2.9", "2.90", -1),
+		Entry("higher minor version", "2.100", "2.90", 1),
+		Entry("higher major version", "3.0", "2.90", 1),
+	)
+})
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/objectstorage/v1/objects"
+)
+
+const swiftService = "swift"
+
+var _ ObjectStorage = &swiftV1{}
+
+// swiftV1 is a SwiftV1 client implementing the ObjectStorage interface.
+type swiftV1 struct {
+	serviceClient *gophercloud.ServiceClient
+}
+
+func newSwiftV1(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*swiftV1, error) {
+	objectStorage, err := openstack.NewObjectStorageV1(providerClient, eo)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize object storage client: %v", err)
+	}
+
+	return &swiftV1{
+		serviceClient: objectStorage,
+	}, nil
+}
+
+// CreateObject creates or replaces an object with the supplied content.
+func (s *swiftV1) CreateObject(ctx context.Context, container, name string, content []byte) error {
+	_, err := objects.Create(ctx, s.serviceClient, container, name, objects.CreateOpts{
+		Content: bytes.NewReader(content),
+	}).Extract()
+	onCall(swiftService)
+	if err != nil {
+		onFailure(swiftService)
+		return err
+	}
+	return nil
+}
+
+// DeleteObject deletes an object.
+func (s *swiftV1) DeleteObject(ctx context.Context, container, name string) error {
+	_, err := objects.Delete(ctx, s.serviceClient, container, name, nil).Extract()
+	onCall(swiftService)
+	if err != nil {
+		onFailure(swiftService)
+		return err
+	}
+	return nil
+}
+
+// CreateTempURL creates a temporary URL to download an object, which is valid for the supplied duration.
+func (s *swiftV1) CreateTempURL(ctx context.Context, container, name string, validity time.Duration) (string, error) {
+	url, err := objects.CreateTempURL(ctx, s.serviceClient, container, name, objects.CreateTempURLOpts{
+		Method: objects.GET,
+		TTL:    int(validity.Seconds()),
+	})
+	onCall(swiftService)
+	if err != nil {
+		onFailure(swiftService)
+		return "", err
+	}
+	return url, nil
+}
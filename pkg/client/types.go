@@ -6,14 +6,29 @@ package client
 
 import (
 	"context"
+	"time"
 
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/backups"
 	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/dns"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/provider"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/trunks"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
 )
 
+// PortWithDNS is a Neutron port including the attributes of the DNS integration.
+type PortWithDNS struct {
+	ports.Port
+	dns.PortDNSExt
+}
+
 // Compute is an interface for communication with Nova service.
 type Compute interface {
 	// CreateServer creates a server.
@@ -24,6 +39,22 @@ type Compute interface {
 	ListServers(ctx context.Context, opts servers.ListOptsBuilder) ([]servers.Server, error)
 	// DeleteServer deletes a server with the supplied ID. If the server does not exist it returns nil.
 	DeleteServer(ctx context.Context, id string) error
+	// SupportsMicroversion returns true if both the compute API and the client support the given microversion.
+	SupportsMicroversion(ctx context.Context, version string) bool
+	// ReplaceServerTags replaces all tags of the server with the supplied ID.
+	ReplaceServerTags(ctx context.Context, id string, tags []string) error
+	// CreateServerGroup creates a server group.
+	CreateServerGroup(ctx context.Context, opts servergroups.CreateOptsBuilder) (*servergroups.ServerGroup, error)
+	// ListServerGroups lists all server groups of the project.
+	ListServerGroups(ctx context.Context) ([]servergroups.ServerGroup, error)
+	// DeleteServerGroup deletes the server group from the supplied ID.
+	DeleteServerGroup(ctx context.Context, id string) error
+	// ListFlavors lists all flavors accessible by the user.
+	ListFlavors(ctx context.Context) ([]flavors.Flavor, error)
+	// GetFlavor retrieves the flavor with the supplied ID.
+	GetFlavor(ctx context.Context, id string) (*flavors.Flavor, error)
+	// ListFlavorExtraSpecs lists the extra specs of the flavor with the supplied ID.
+	ListFlavorExtraSpecs(ctx context.Context, id string) (map[string]string, error)
 
 	// FlavorIDFromName resolves the given flavor name to a unique ID.
 	FlavorIDFromName(ctx context.Context, name string) (string, error)
@@ -35,16 +66,42 @@ type Compute interface {
 type Network interface {
 	// GetSubnet fetches the subnet data from the supplied ID.
 	GetSubnet(ctx context.Context, id string) (*subnets.Subnet, error)
+	// GetNetworkProviderAttributes fetches the provider attributes of the network with the supplied ID.
+	GetNetworkProviderAttributes(ctx context.Context, id string) (*provider.NetworkProviderExt, error)
+	// GetNetworkPortSecurity fetches whether port security is enabled on the network with the supplied ID.
+	GetNetworkPortSecurity(ctx context.Context, id string) (bool, error)
 
 	// CreatePort creates a Neutron port.
 	CreatePort(ctx context.Context, opts ports.CreateOptsBuilder) (*ports.Port, error)
 	// ListPorts lists all ports.
 	ListPorts(ctx context.Context, opts ports.ListOptsBuilder) ([]ports.Port, error)
+	// ListPortsWithDNS lists all ports including the attributes of the DNS integration.
+	ListPortsWithDNS(ctx context.Context, opts ports.ListOptsBuilder) ([]PortWithDNS, error)
 	// UpdatePort updates the port from the supplied ID.
 	UpdatePort(ctx context.Context, id string, opts ports.UpdateOptsBuilder) error
 	// DeletePort deletes the port from the supplied ID.
 	DeletePort(ctx context.Context, id string) error
 
+	// CreateFloatingIP allocates a floating IP.
+	CreateFloatingIP(ctx context.Context, opts floatingips.CreateOptsBuilder) (*floatingips.FloatingIP, error)
+	// ListFloatingIPs lists all floating IPs.
+	ListFloatingIPs(ctx context.Context, opts floatingips.ListOptsBuilder) ([]floatingips.FloatingIP, error)
+	// UpdateFloatingIP updates the floating IP from the supplied ID.
+	UpdateFloatingIP(ctx context.Context, id string, opts floatingips.UpdateOptsBuilder) error
+	// DeleteFloatingIP releases the floating IP from the supplied ID.
+	DeleteFloatingIP(ctx context.Context, id string) error
+	// TagFloatingIP replaces the tags of the floating IP from the supplied ID.
+	TagFloatingIP(ctx context.Context, id string, tags []string) error
+
+	// CreateTrunk creates a Neutron trunk.
+	CreateTrunk(ctx context.Context, opts trunks.CreateOptsBuilder) (*trunks.Trunk, error)
+	// ListTrunks lists all trunks.
+	ListTrunks(ctx context.Context, opts trunks.ListOptsBuilder) ([]trunks.Trunk, error)
+	// AddSubports adds subports to the trunk from the supplied ID.
+	AddSubports(ctx context.Context, id string, opts trunks.AddSubportsOptsBuilder) error
+	// DeleteTrunk deletes the trunk from the supplied ID.
+	DeleteTrunk(ctx context.Context, id string) error
+
 	// NetworkIDFromName resolves the given network name to a unique ID.
 	NetworkIDFromName(ctx context.Context, name string) (string, error)
 	// GroupIDFromName resolves the given security group name to a unique ID.
@@ -55,6 +112,30 @@ type Network interface {
 	TagPort(ctx context.Context, id string, tags []string) error
 }
 
+// Image is an interface for communication with Glance service.
+type Image interface {
+	// ListImages lists all images matching the supplied options.
+	ListImages(ctx context.Context, opts images.ListOptsBuilder) ([]images.Image, error)
+}
+
+// ObjectStorage is an interface for communication with Swift service.
+type ObjectStorage interface {
+	// CreateObject creates or replaces an object with the supplied content.
+	CreateObject(ctx context.Context, container, name string, content []byte) error
+	// DeleteObject deletes an object.
+	DeleteObject(ctx context.Context, container, name string) error
+	// CreateTempURL creates a temporary URL to download an object, which is valid for the supplied duration.
+	CreateTempURL(ctx context.Context, container, name string, validity time.Duration) (string, error)
+}
+
+// Baremetal is an interface for communication with Ironic service.
+type Baremetal interface {
+	// GetNode retrieves information about a node.
+	GetNode(ctx context.Context, id string) (*nodes.Node, error)
+	// ListNodes lists the nodes matching the supplied options.
+	ListNodes(ctx context.Context, opts nodes.ListOptsBuilder) ([]nodes.Node, error)
+}
+
 // Storage is an interface for communication with Cinder service.
 type Storage interface {
 	// CreateVolume creates a Cinder volume.
@@ -67,4 +148,12 @@ type Storage interface {
 	VolumeIDFromName(ctx context.Context, name string) (string, error)
 	// ListVolumes lists all volumes
 	ListVolumes(ctx context.Context, opts volumes.ListOptsBuilder) ([]volumes.Volume, error)
+	// SnapshotIDFromName resolves the given snapshot name to a unique ID.
+	SnapshotIDFromName(ctx context.Context, name string) (string, error)
+	// CreateBackup creates a backup of a volume.
+	CreateBackup(ctx context.Context, opts backups.CreateOptsBuilder) (*backups.Backup, error)
+	// GetBackup retrieves information about a backup.
+	GetBackup(ctx context.Context, id string) (*backups.Backup, error)
+	// ListBackups lists all backups
+	ListBackups(ctx context.Context, opts backups.ListOptsBuilder) ([]backups.Backup, error)
 }
@@ -12,7 +12,6 @@ import (
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
@@ -71,36 +70,82 @@ func (p *OpenstackDriver) CreateMachine(ctx context.Context, req *driver.CreateM
 		klog.Errorf("failed to construct context for the request: %v", err)
 		return nil, status.Error(mapErrorToCode(err), fmt.Sprintf("failed to construct context for the request: %v", err))
 	}
+	ex.MachineClassName = req.MachineClass.Name
+	ex.AsyncServerCreation = p.asyncServerCreation
+
+	nodeName, err := ex.NodeName(req.Machine.Name)
+	if err != nil {
+		return nil, status.Error(mapErrorToCode(err), err.Error())
+	}
 
 	server, err := ex.CreateMachine(ctx, req.Machine.Name, req.Secret.Data[cloudprovider.UserData])
 	if err != nil {
 		klog.Errorf("machine creation for machine %q failed with: %v", req.Machine.Name, err)
 		return nil, status.Error(mapErrorToCode(err), err.Error())
 	}
+	klog.V(2).Infof("machine %q has been created in availability zone %q", req.Machine.Name, server.AvailabilityZone)
 
 	response := driver.CreateMachineResponse{
 		ProviderID: server.ProviderID,
-		NodeName:   req.Machine.Name,
+		NodeName:   nodeName,
+		Addresses:  nodeAddresses(server.ServerAddresses),
 	}
 
-	if len(server.InternalIPs) > 0 {
-		addresses := make([]corev1.NodeAddress, 0, len(server.InternalIPs))
+	return &response, nil
+}
 
-		for _, ip := range server.InternalIPs {
-			addresses = append(addresses, corev1.NodeAddress{
-				Type:    corev1.NodeInternalIP,
-				Address: ip,
-			})
-		}
-		response.Addresses = addresses
+// InitializeMachine handles VM initialization for openstack VM's. It performs the setup of the server's ports, which can
+// only happen after the server has been built. If the server is not yet ready, an Uninitialized error code is returned
+// so that the initialization is retried.
+func (p *OpenstackDriver) InitializeMachine(ctx context.Context, req *driver.InitializeMachineRequest) (*driver.InitializeMachineResponse, error) {
+	klog.V(2).Infof("InitializeMachine request has been received for %q", req.Machine.Name)
+	defer klog.V(2).Infof("InitializeMachine request has been processed for %q", req.Machine.Name)
+
+	// Check if incoming provider in the MachineClass is a provider we support
+	if req.MachineClass.Provider != openstackProvider {
+		err := fmt.Errorf("requested for Provider '%s', we only support '%s'", req.MachineClass.Provider, openstackProvider)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	return &response, nil
-}
+	providerConfig, err := p.decodeProviderSpec(req.MachineClass.ProviderSpec)
+	if err != nil {
+		klog.Errorf("decoding provider spec for machine class %q failed with: %v", req.MachineClass.Name, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validation.ValidateRequest(providerConfig, req.Secret); err != nil {
+		klog.Errorf("validating request for machine %q failed with: %v", req.Machine.Name, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	factory, err := client.NewFactoryFromSecret(ctx, req.Secret)
+	if err != nil {
+		klog.Errorf("failed to construct OpenStack client: %v", err)
+		return nil, status.Error(mapErrorToCode(err), fmt.Sprintf("failed to construct OpenStack client: %v", err))
+	}
+
+	ex, err := executor.NewExecutor(factory, providerConfig)
+	if err != nil {
+		klog.Errorf("failed to construct context for the request: %v", err)
+		return nil, status.Error(mapErrorToCode(err), fmt.Sprintf("failed to construct context for the request: %v", err))
+	}
+	ex.MachineClassName = req.MachineClass.Name
+
+	nodeName, err := ex.NodeName(req.Machine.Name)
+	if err != nil {
+		return nil, status.Error(mapErrorToCode(err), err.Error())
+	}
 
-// InitializeMachine handles VM initialization for openstack VM's. Currently, un-implemented.
-func (p *OpenstackDriver) InitializeMachine(_ context.Context, _ *driver.InitializeMachineRequest) (*driver.InitializeMachineResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Openstack Provider does not yet implement InitializeMachine")
+	server, err := ex.InitializeMachine(ctx, req.Machine.Name, req.Machine.Spec.ProviderID)
+	if err != nil {
+		klog.Errorf("machine initialization for machine %q failed with: %v", req.Machine.Name, err)
+		return nil, status.Error(mapErrorToCode(err), err.Error())
+	}
+
+	return &driver.InitializeMachineResponse{
+		ProviderID: server.ProviderID,
+		NodeName:   nodeName,
+		Addresses:  nodeAddresses(server.ServerAddresses),
+	}, nil
 }
 
 // DeleteMachine handles a machine deletion request
@@ -145,12 +190,70 @@ func (p *OpenstackDriver) DeleteMachine(ctx context.Context, req *driver.DeleteM
 }
 
 // GetMachineStatus handles a machine get status request
-func (p *OpenstackDriver) GetMachineStatus(_ context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
+func (p *OpenstackDriver) GetMachineStatus(ctx context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
 	// Log messages to track start and end of request
 	klog.V(2).Infof("GetMachineStatus request has been received for %q", req.Machine.Name)
-	defer klog.V(2).Infof("GetMachineStatus is not implemented")
+	defer klog.V(2).Infof("GetMachineStatus request has been processed for %q", req.Machine.Name)
+
+	// Check if incoming provider in the MachineClass is a provider we support
+	if req.MachineClass.Provider != openstackProvider {
+		err := fmt.Errorf("requested for Provider '%s', we only support '%s'", req.MachineClass.Provider, openstackProvider)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	providerConfig, err := p.decodeProviderSpec(req.MachineClass.ProviderSpec)
+	if err != nil {
+		klog.Errorf("decoding provider spec for machine class %q failed with: %v", req.MachineClass.Name, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validation.ValidateRequest(providerConfig, req.Secret); err != nil {
+		klog.Errorf("validating request for machine %q failed with: %v", req.Machine.Name, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	factory, err := client.NewFactoryFromSecret(ctx, req.Secret)
+	if err != nil {
+		klog.Errorf("failed to construct OpenStack client: %v", err)
+		return nil, status.Error(mapErrorToCode(err), fmt.Sprintf("failed to construct OpenStack client: %v", err))
+	}
+
+	ex, err := executor.NewExecutor(factory, providerConfig)
+	if err != nil {
+		klog.Errorf("failed to construct context for the request: %v", err)
+		return nil, status.Error(mapErrorToCode(err), fmt.Sprintf("failed to construct context for the request: %v", err))
+	}
+	ex.MachineClassName = req.MachineClass.Name
+
+	nodeName, err := ex.NodeName(req.Machine.Name)
+	if err != nil {
+		return nil, status.Error(mapErrorToCode(err), err.Error())
+	}
+
+	machineStatus, err := ex.GetMachineStatus(ctx, req.Machine.Name, req.Machine.Spec.ProviderID)
+	if err != nil {
+		return nil, status.Error(mapErrorToCode(err), err.Error())
+	}
+
+	response := &driver.GetMachineStatusResponse{
+		ProviderID: machineStatus.ProviderID,
+		NodeName:   nodeName,
+		Addresses:  nodeAddresses(machineStatus.ServerAddresses),
+	}
+
+	// The response is returned along with the error, as the machine controller relies on the provider ID and the node
+	// name of machines that are not yet initialized.
+	if code := mapServerStatusToCode(machineStatus.Status); code != codes.OK {
+		msg := fmt.Sprintf("server for machine %q is in status %q", req.Machine.Name, machineStatus.Status)
+		if machineStatus.Fault != "" {
+			msg = fmt.Sprintf("%s, fault: %s", msg, machineStatus.Fault)
+		}
+		return response, status.Error(code, msg)
+	}
+	if !machineStatus.Initialized {
+		return response, status.Error(codes.Uninitialized, fmt.Sprintf("server for machine %q has not been initialized", req.Machine.Name))
+	}
 
-	return nil, status.Error(codes.Unimplemented, "method not implemented")
+	return response, nil
 }
 
 // ListMachines lists all the machines possibly created by a providerSpec
@@ -32,6 +32,17 @@ var (
 	// For example, reverse lookups from names to IDs may yield multiple matches because names are not unique in most
 	// OpenStack resources. In case this case, where a unique ID could not be determined an ErrMultipleFound is returned.
 	ErrMultipleFound = fmt.Errorf("multiple resources found")
+
+	// ErrNotInitialized is returned when a server exists, but can not be initialized yet. The initialization has to be retried.
+	ErrNotInitialized = fmt.Errorf("server not initialized")
+
+	// ErrInvalidArgument is returned when the provider spec requests something the OpenStack cloud can not fulfill, e.g.
+	// an SR-IOV port on a network without physical network.
+	ErrInvalidArgument = fmt.Errorf("invalid argument")
+
+	// ErrDeletionPending is returned when the deletion of a resource of a machine has been started or has to wait for
+	// another resource, but is not finished yet. The deletion has to be retried.
+	ErrDeletionPending = fmt.Errorf("deletion pending")
 )
 
 // ErrFlavorNotFound is returned when there is no flavor can be matched with the specified flavor name.
@@ -42,5 +53,23 @@ type ErrFlavorNotFound struct {
 }
 
 func (e ErrFlavorNotFound) Error() string {
+	if e.Flavor == "" {
+		return "Unable to find a matching flavor"
+	}
 	return fmt.Sprintf("Unable to find flavor with name %s", e.Flavor)
 }
+
+// ErrVolumeNotDeleted is returned when a volume of a machine could not be deleted, e.g. because it is still attached to a
+// server or it remains in Cinder after the deletion.
+type ErrVolumeNotDeleted struct {
+	VolumeID string
+	Err      error
+}
+
+func (e ErrVolumeNotDeleted) Error() string {
+	return fmt.Sprintf("volume [ID=%q] could not be deleted: %v", e.VolumeID, e.Err)
+}
+
+func (e ErrVolumeNotDeleted) Unwrap() error {
+	return e.Err
+}
@@ -5,21 +5,37 @@
 package executor
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/backups"
 	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/keypairs"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/portsbinding"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/portsecurity"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/trunks"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
+	netutils "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
 
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
 	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
 )
@@ -29,16 +45,75 @@ import (
 type Executor struct {
 	Compute client.Compute
 	Network client.Network
+	// Image is only set if the image is selected by the ImageSelector.
+	Image   client.Image
 	Storage client.Storage
-	Config  *api.MachineProviderConfig
+	// ObjectStorage is only set if user data can be staged in Swift.
+	ObjectStorage client.ObjectStorage
+	// Baremetal is only set in bare-metal mode.
+	Baremetal client.Baremetal
+	Config    *api.MachineProviderConfig
+	// MachineClassName is the name of the machine class, which can be used in the hostname, FQDN and description
+	// templates.
+	MachineClassName string
+	// AsyncServerCreation disables waiting for the server to become active in CreateMachine. The server build is tracked
+	// by the machine initialization instead. It is ignored for machine classes with fallback availability zones, as
+	// the server can only be moved to the next availability zone while it is created.
+	AsyncServerCreation bool
+	// PollInterval is the interval in which the status of servers, volumes and nodes is polled while waiting for them.
+	// Defaults to defaultPollInterval.
+	PollInterval time.Duration
 }
 
-// CreateMachineResult represents the result of a CreateMachine call (internal IP addresses + provider ID of VM).
-type CreateMachineResult struct {
-	ProviderID  string
+// ServerAddresses are the addresses of a server built from its Neutron ports.
+type ServerAddresses struct {
+	// InternalIPs are the fixed IP addresses of the ports, starting with the ones of the port in the primary network.
 	InternalIPs []string
+	// ExternalIPs are the floating IP addresses associated with the ports.
+	ExternalIPs []string
+	// Hostname is the DNS name of the port in the primary network, if set by the Neutron DNS integration.
+	Hostname string
+	// InternalDNS are the fully qualified domain names assigned to the ports by the Neutron DNS integration.
+	InternalDNS []string
+}
+
+// CreateMachineResult represents the result of a CreateMachine call (addresses + provider ID of VM).
+type CreateMachineResult struct {
+	ProviderID string
+	ServerAddresses
+	// AvailabilityZone is the availability zone the server was created in.
+	AvailabilityZone string
+}
+
+// GetMachineStatusResult represents the result of a GetMachineStatus call (addresses + provider ID + status of VM).
+type GetMachineStatusResult struct {
+	ProviderID string
+	ServerAddresses
+	// Status is the status of the server as reported by Nova.
+	Status string
+	// Fault contains the fault message of the server if it is in error.
+	Fault string
+	// Initialized is true if the server's ports have been set up by InitializeMachine.
+	Initialized bool
+}
+
+// InitializeMachineResult represents the result of a InitializeMachine call (addresses + provider ID of VM).
+type InitializeMachineResult struct {
+	ProviderID string
+	ServerAddresses
 }
 
+// segmentationTypeVLAN is the segmentation type of trunk subports.
+const segmentationTypeVLAN = "vlan"
+
+const (
+	// serverMetadataImageID is the server metadata key recording the ID of the image selected by the image selector.
+	serverMetadataImageID = "mcm.gardener.cloud/image-id"
+	// serverMetadataAvailabilityZone is the server metadata key recording the availability zone the server was created
+	// in if fallback availability zones are configured.
+	serverMetadataAvailabilityZone = "mcm.gardener.cloud/availability-zone"
+)
+
 // NewExecutor returns a new instance of Executor.
 func NewExecutor(factory *client.Factory, config *api.MachineProviderConfig) (*Executor, error) {
 	computeClient, err := factory.Compute(client.WithRegion(config.Spec.Region))
@@ -63,43 +138,156 @@ func NewExecutor(factory *client.Factory, config *api.MachineProviderConfig) (*E
 		Storage: storageClient,
 		Config:  config,
 	}
+	if config.Spec.ImageSelector != nil {
+		ex.Image, err = factory.Image(client.WithRegion(config.Spec.Region))
+		if err != nil {
+			klog.Errorf("failed to create image client for executor: %v", err)
+			return nil, err
+		}
+	}
+	if config.Spec.UserDataOptions != nil && config.Spec.UserDataOptions.SwiftContainer != "" {
+		ex.ObjectStorage, err = factory.ObjectStorage(client.WithRegion(config.Spec.Region))
+		if err != nil {
+			klog.Errorf("failed to create object storage client for executor: %v", err)
+			return nil, err
+		}
+	}
+	if config.Spec.BareMetal != nil {
+		ex.Baremetal, err = factory.Baremetal(client.WithRegion(config.Spec.Region))
+		if err != nil {
+			klog.Errorf("failed to create baremetal client for executor: %v", err)
+			return nil, err
+		}
+	}
 	return ex, nil
 }
 
-// getServerIPs assumes the server has exactly one network interface
-// and extracts its internal IP addresses.
-func getServerIPs(server *servers.Server) ([]string, error) {
-	ips := make([]string, 0)
-
-	if len(server.Addresses) != 1 {
-		return nil, fmt.Errorf("expected 1 network, but found %d", len(server.Addresses))
+// serverAddresses builds the addresses of the server with the supplied ID from its ports. The port in the primary
+// network comes first, followed by the ports managed by MCM in the order of the networks and the remaining ports. Within
+// each port, the addresses of the family of the primary pod network CIDR come first, so that the primary address family
+// of the node matches the one of the pod network.
+func (ex *Executor) serverAddresses(ctx context.Context, machineName, serverID string) (ServerAddresses, error) {
+	primaryNetworkID, err := ex.primaryNetworkID(ctx)
+	if err != nil {
+		return ServerAddresses{}, err
+	}
+	serverPorts, err := ex.Network.ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: serverID})
+	if err != nil {
+		return ServerAddresses{}, fmt.Errorf("failed to list ports of server [ID=%q]: %w", serverID, err)
 	}
+	ex.sortServerPorts(machineName, primaryNetworkID, serverPorts)
 
-	// Format of the addresses field: https://docs.openstack.org/api-ref/compute/#list-servers-detailed.
-	for _, networkAddresses := range server.Addresses {
-		addrList, ok := networkAddresses.([]any)
-		if !ok {
-			return nil, fmt.Errorf("could not assert network addresses to slice")
+	var (
+		addresses ServerAddresses
+		ipv6First = netutils.IsIPv6CIDRString(ex.primaryPodNetworkCIDR())
+	)
+	for index, port := range serverPorts {
+		var portIPs []string
+		for _, fixedIP := range port.FixedIPs {
+			portIPs = append(portIPs, fixedIP.IPAddress)
 		}
+		sortIPsByFamily(portIPs, ipv6First)
+		addresses.InternalIPs = append(addresses.InternalIPs, portIPs...)
 
-		// Iterate through the addresses (may be IPv4, IPv6).
-		for _, addrData := range addrList {
-			addressMap, ok := addrData.(map[string]any)
-			if !ok {
-				continue
-			}
+		floatingIPs, err := ex.Network.ListFloatingIPs(ctx, floatingips.ListOpts{PortID: port.ID})
+		if err != nil {
+			return ServerAddresses{}, fmt.Errorf("failed to list floating IPs of port [ID=%q]: %w", port.ID, err)
+		}
+		for _, floatingIP := range floatingIPs {
+			addresses.ExternalIPs = append(addresses.ExternalIPs, floatingIP.FloatingIP)
+		}
 
-			if ipAddress, ok := addressMap["addr"].(string); ok {
-				ips = append(ips, ipAddress)
+		// the DNS assignment contains generated names unless a DNS name is set on the port
+		if port.DNSName == "" {
+			continue
+		}
+		if index == 0 && ex.isPrimaryPort(machineName, primaryNetworkID, port.Port) {
+			addresses.Hostname = port.DNSName
+		}
+		for _, assignment := range port.DNSAssignment {
+			if fqdn := strings.TrimSuffix(assignment["fqdn"], "."); fqdn != "" && !slices.Contains(addresses.InternalDNS, fqdn) {
+				addresses.InternalDNS = append(addresses.InternalDNS, fqdn)
 			}
 		}
 	}
 
-	return ips, nil
+	return addresses, nil
+}
+
+// sortServerPorts sorts the ports of the server, so that the port in the primary network comes first, followed by the
+// ports managed by MCM in the order of the networks and the remaining ports ordered by their ID.
+func (ex *Executor) sortServerPorts(machineName, primaryNetworkID string, serverPorts []client.PortWithDNS) {
+	managedPorts := ex.managedPorts(machineName)
+	rank := func(port ports.Port) int {
+		if ex.isPrimaryPort(machineName, primaryNetworkID, port) {
+			return 0
+		}
+		if index := slices.IndexFunc(managedPorts, func(mp managedPort) bool { return mp.name == port.Name }); index >= 0 {
+			return index + 1
+		}
+		return len(managedPorts) + 1
+	}
+	slices.SortStableFunc(serverPorts, func(a, b client.PortWithDNS) int {
+		return cmp.Or(cmp.Compare(rank(a.Port), rank(b.Port)), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// isPrimaryPort returns true if the port is in the primary network. The primary network is the network specified by
+// NetworkID, or the network marked as primary, the first pod network or the first network of Networks in this order.
+// The port of a managed network is identified by its name, any other port by the supplied ID of the primary network.
+func (ex *Executor) isPrimaryPort(machineName, primaryNetworkID string, port ports.Port) bool {
+	networks := ex.Config.Spec.Networks
+	if ex.Config.Spec.NetworkID != "" || !ex.Config.Spec.ManagedPorts {
+		return primaryNetworkID != "" && port.NetworkID == primaryNetworkID
+	}
+
+	index := ex.primaryNetworkIndex()
+	return index < len(networks) && port.Name == portName(machineName, index, networks[index])
+}
+
+// primaryNetworkIndex returns the index of the primary network in Networks.
+func (ex *Executor) primaryNetworkIndex() int {
+	networks := ex.Config.Spec.Networks
+	index := slices.IndexFunc(networks, func(network api.OpenStackNetwork) bool { return network.Primary })
+	if index < 0 {
+		index = max(slices.IndexFunc(networks, func(network api.OpenStackNetwork) bool { return network.PodNetwork }), 0)
+	}
+	return index
+}
+
+// primaryNetworkID resolves the ID of the primary network if its ports are not managed by MCM.
+func (ex *Executor) primaryNetworkID(ctx context.Context) (string, error) {
+	networks := ex.Config.Spec.Networks
+	if ex.Config.Spec.NetworkID != "" {
+		return ex.Config.Spec.NetworkID, nil
+	}
+	if ex.Config.Spec.ManagedPorts || len(networks) == 0 {
+		return "", nil
+	}
+
+	network := networks[ex.primaryNetworkIndex()]
+	if network.Id != "" {
+		return network.Id, nil
+	}
+	return ex.Network.NetworkIDFromName(ctx, network.Name)
+}
+
+// primaryPodNetworkCIDR returns the first configured pod network CIDR.
+func (ex *Executor) primaryPodNetworkCIDR() string {
+	if ex.Config.Spec.PodNetworkCidr != "" {
+		return ex.Config.Spec.PodNetworkCidr
+	}
+	if len(ex.Config.Spec.PodNetworkCIDRs) > 0 {
+		return ex.Config.Spec.PodNetworkCIDRs[0]
+	}
+	return ""
 }
 
 // CreateMachine creates a new OpenStack server instance and waits until it reports "ACTIVE".
 // If there is an error during the build process, or if the building phase timeouts, it will delete any artifacts created.
+// If AsyncServerCreation is enabled, CreateMachine returns as soon as the server has been accepted by Nova instead.
+// The tagging of the server's ports is deferred to InitializeMachine. The floating IP is associated by CreateMachine and
+// reported as external IP, InitializeMachine only associates it if the server creation is asynchronous.
 func (ex *Executor) CreateMachine(ctx context.Context, machineName string, userData []byte) (*CreateMachineResult, error) {
 	var (
 		server *servers.Server
@@ -120,108 +308,312 @@ func (ex *Executor) CreateMachine(ctx context.Context, machineName string, userD
 	} else if !errors.Is(err, ErrNotFound) {
 		return nil, err
 	} else {
-		// clean-up function when creation fails in an intermediate step
-		serverNetworks, err := ex.resolveServerNetworks(ctx, machineName)
+		server, err = ex.createServer(ctx, machineName, userData)
 		if err != nil {
-			return nil, deleteOnFail(fmt.Errorf("failed to resolve server [Name=%q] networks: %w", machineName, err))
+			// clean-up function when creation fails in an intermediate step
+			return nil, deleteOnFail(err)
 		}
+	}
+
+	if ex.createsServersAsync() {
+		klog.V(3).Infof("server [ID=%q] has been accepted, its build is tracked by the machine initialization", server.ID)
+		return &CreateMachineResult{
+			ProviderID:       ex.providerID(server),
+			AvailabilityZone: server.AvailabilityZone,
+		}, nil
+	}
 
-		server, err = ex.deployServer(ctx, machineName, userData, serverNetworks)
+	// The server information when status is ACTIVE has addresses field populated
+	activeServer := server
+	if server.Status != client.ServerStatusActive {
+		activeServer, err = ex.waitForServerStatus(ctx,
+			server.ID,
+			[]string{client.ServerStatusBuild},
+			[]string{client.ServerStatusActive}, ex.serverBuildTimeout())
 		if err != nil {
-			return nil, deleteOnFail(fmt.Errorf("failed to deploy server [Name=%q]: %w", machineName, err))
+			return nil, deleteOnFail(fmt.Errorf("error waiting for server [ID=%q] to reach target status: %w", server.ID, err))
 		}
 	}
 
-	// The server information when status is ACTIVE has addresses field populated
-	var activeServer *servers.Server
-	activeServer, err = ex.waitForServerStatus(ctx,
-		server.ID,
-		[]string{client.ServerStatusBuild},
-		[]string{client.ServerStatusActive}, 1200)
+	serverPorts, err := ex.listServerPorts(ctx, activeServer.ID)
 	if err != nil {
-		return nil, deleteOnFail(fmt.Errorf("error waiting for server [ID=%q] to reach target status: %w", server.ID, err))
+		return nil, deleteOnFail(err)
+	}
+
+	if err := ex.reconcileServerPortsAllowedAddressPairs(ctx, machineName, serverPorts); err != nil {
+		return nil, deleteOnFail(fmt.Errorf("failed to patch server [ID=%q] ports: %w", activeServer.ID, err))
 	}
 
-	if err := ex.patchServerPortsForPodNetwork(ctx, activeServer.ID); err != nil {
-		return nil, deleteOnFail(fmt.Errorf("failed to patch server [ID=%q] ports: %s", server.ID, err))
+	if ex.Config.Spec.FloatingIP != nil {
+		if _, err := ex.ensureFloatingIP(ctx, machineName, serverPorts); err != nil {
+			return nil, deleteOnFail(fmt.Errorf("failed to associate a floating IP with server [ID=%q]: %w", activeServer.ID, err))
+		}
 	}
 
-	var internalIPs []string
-	internalIPs, err = getServerIPs(activeServer)
+	addresses, err := ex.serverAddresses(ctx, machineName, activeServer.ID)
 	if err != nil {
-		klog.Infof("failed to extract internal IPs [ID=%q] ports: %s", activeServer.ID, err)
+		klog.Infof("failed to get the addresses of server [ID=%q]: %s", activeServer.ID, err)
 	}
 
 	return &CreateMachineResult{
-		ProviderID:  encodeProviderID(ex.Config.Spec.Region, activeServer.ID),
-		InternalIPs: internalIPs,
+		ProviderID:       ex.providerID(activeServer),
+		ServerAddresses:  addresses,
+		AvailabilityZone: activeServer.AvailabilityZone,
 	}, nil
 }
 
-// resolveServerNetworks resolves the network configuration for the server.
+// createServer creates the server together with its ports and volumes. The server is created in AvailabilityZone first.
+// If no valid host can be found for it, the server and its resources are cleaned up and the creation is retried in the
+// FallbackAvailabilityZones in the given order. The availability zone is recorded in the metadata of the server. The user data is prepared once, user data staged in Swift is kept for
+// all attempts.
+func (ex *Executor) createServer(ctx context.Context, machineName string, userData []byte) (*servers.Server, error) {
+	zones := append([]string{ex.Config.Spec.AvailabilityZone}, ex.Config.Spec.FallbackAvailabilityZones...)
+
+	userData, err := ex.prepareUserData(ctx, machineName, userData)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, zone := range zones {
+		serverNetworks, err := ex.resolveServerNetworks(ctx, machineName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve server [Name=%q] networks: %w", machineName, err)
+		}
+
+		server, err := ex.deployServer(ctx, machineName, userData, serverNetworks, zone)
+		if err == nil {
+			if server.AvailabilityZone == "" {
+				server.AvailabilityZone = zone
+			}
+			if len(ex.Config.Spec.FallbackAvailabilityZones) > 0 {
+				server.Metadata = withServerMetadata(server.Metadata, serverMetadataAvailabilityZone, zone)
+			}
+			return server, nil
+		}
+		if !isNoValidHost(err) || index == len(zones)-1 {
+			return nil, fmt.Errorf("failed to deploy server [Name=%q]: %w", machineName, err)
+		}
+
+		klog.Warningf("no valid host found for server [Name=%q] in availability zone %q, retrying in availability zone %q", machineName, zone, zones[index+1])
+		if err := ex.cleanupFailedAttempt(ctx, machineName); err != nil {
+			return nil, fmt.Errorf("error cleaning up server [Name=%q] in availability zone %q: %w", machineName, zone, err)
+		}
+	}
+	return nil, fmt.Errorf("failed to deploy server [Name=%q]: no availability zone specified", machineName)
+}
+
+// createsServersAsync returns true if CreateMachine returns as soon as the server has been accepted by Nova. Servers
+// with fallback availability zones are always awaited, as a build failing for lack of a valid host is only detected
+// once the build has finished.
+func (ex *Executor) createsServersAsync() bool {
+	return ex.AsyncServerCreation && len(ex.Config.Spec.FallbackAvailabilityZones) == 0
+}
+
+// providerID returns the provider ID of the server. Servers which recorded the availability zone they were created in
+// carry it in their provider ID, as it can differ from the configured availability zone.
+func (ex *Executor) providerID(server *servers.Server) string {
+	if zone := server.Metadata[serverMetadataAvailabilityZone]; zone != "" {
+		return encodeZonalProviderID(ex.Config.Spec.Region, zone, server.ID)
+	}
+	return encodeProviderID(ex.Config.Spec.Region, server.ID)
+}
+
+// cleanupFailedAttempt removes the server and the ports and volumes of a failed attempt to deploy the server in an
+// availability zone. Unlike DeleteMachine it ignores the deletion policies and waits for the volumes to be gone, as they
+// are bound to the availability zone and must not be reused by the next attempt. The server group and the staged user
+// data are kept for the next attempt.
+func (ex *Executor) cleanupFailedAttempt(ctx context.Context, machineName string) error {
+	server, err := ex.getMachineByName(ctx, machineName)
+	if err == nil {
+		klog.V(1).Infof("deleting server [Name=%s, ID=%s] of the failed attempt", server.Name, server.ID)
+		if err := ex.deleteServer(ctx, server.ID); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if err := ex.deletePorts(ctx, machineName); err != nil {
+		return err
+	}
+
+	var volumeNames []string
+	if ex.managesRootVolume() {
+		volumeNames = append(volumeNames, machineName)
+	}
+	for _, dataVolume := range ex.Config.Spec.DataVolumes {
+		volumeNames = append(volumeNames, dataVolumeName(machineName, dataVolume))
+	}
+	for _, name := range volumeNames {
+		if err := ex.waitForVolumeDeletion(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareUserData makes sure that the user data fits into the size limit of Nova. Oversized user data is gzip compressed
+// or staged in Swift according to the UserDataOptions.
+func (ex *Executor) prepareUserData(ctx context.Context, machineName string, userData []byte) ([]byte, error) {
+	if fitsUserDataLimit(userData) {
+		return userData, nil
+	}
+
+	options := ptr.Deref(ex.Config.Spec.UserDataOptions, api.UserDataOptions{})
+	if ptr.Deref(options.Compress, true) {
+		compressed, err := gzipUserData(userData)
+		if err != nil {
+			return nil, fmt.Errorf("error compressing user data: %w", err)
+		}
+		if fitsUserDataLimit(compressed) {
+			klog.V(2).Infof("compressed user data of server [Name=%q] from %d to %d bytes", machineName, len(userData), len(compressed))
+			return compressed, nil
+		}
+	}
+
+	if options.SwiftContainer == "" {
+		return nil, fmt.Errorf("%w: user data of %d bytes exceeds the limit of Nova and can not be staged without Swift container", ErrInvalidArgument, len(userData))
+	}
+
+	klog.V(2).Infof("staging user data of server [Name=%q] in Swift container %q", machineName, options.SwiftContainer)
+	if err := ex.ObjectStorage.CreateObject(ctx, options.SwiftContainer, machineName, userData); err != nil {
+		return nil, fmt.Errorf("error staging user data in Swift container %q: %w", options.SwiftContainer, err)
+	}
+	validity := 24 * time.Hour
+	if options.TempURLValidity != nil {
+		validity = options.TempURLValidity.Duration
+	}
+	url, err := ex.ObjectStorage.CreateTempURL(ctx, options.SwiftContainer, machineName, validity)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp URL for staged user data: %w", err)
+	}
+
+	// cloud-init downloads and processes the user data referenced by the include stub
+	return []byte(fmt.Sprintf("#include\n%s\n", url)), nil
+}
+
+// resolveServerNetworks resolves the network configuration for the server. The ports managed by MCM are created upfront,
+// the ports of the remaining networks are created by Nova.
 func (ex *Executor) resolveServerNetworks(ctx context.Context, machineName string) ([]servers.Network, error) {
 	var (
 		networkID      = ex.Config.Spec.NetworkID
-		subnetID       = ex.Config.Spec.SubnetID
-		networks       = ex.Config.Spec.Networks
 		serverNetworks = make([]servers.Network, 0)
 	)
 
 	klog.V(3).Infof("resolving network setup for machine [Name=%q]", machineName)
-	// If SubnetID is specified in addition to NetworkID, we have to preallocate a Neutron Port to force the VMs to get IP from the subnet's range.
-	if ex.isUserManagedNetwork() {
+	managedPorts := ex.managedPorts(machineName)
+	if len(managedPorts) == 0 && networkID != "" {
+		klog.V(3).Infof("deploying in network [ID=%q]", networkID)
+		serverNetworks = append(serverNetworks, servers.Network{UUID: networkID})
+		return serverNetworks, nil
+	}
+
+	// without managed ports Nova creates the ports of the networks
+	if len(managedPorts) == 0 {
+		for _, network := range ex.Config.Spec.Networks {
+			resolvedNetworkID := network.Id
+			if resolvedNetworkID == "" {
+				var err error
+				resolvedNetworkID, err = ex.Network.NetworkIDFromName(ctx, network.Name)
+				if err != nil {
+					return nil, err
+				}
+			}
+			serverNetworks = append(serverNetworks, servers.Network{UUID: resolvedNetworkID})
+		}
+		return serverNetworks, nil
+	}
+
+	for _, mp := range managedPorts {
 		// check that all subnets exist upfront
-		if subnetID != nil {
-			if _, err := ex.Network.GetSubnet(ctx, *subnetID); err != nil {
-				return nil, fmt.Errorf("subnet [ID=%q] not found: %w", *subnetID, err)
+		for _, ip := range mp.fixedIPs {
+			if ip.SubnetID == "" {
+				continue
+			}
+			if _, err := ex.Network.GetSubnet(ctx, ip.SubnetID); err != nil {
+				return nil, fmt.Errorf("subnet [ID=%q] not found: %w", ip.SubnetID, err)
+			}
+		}
+
+		resolvedNetworkID := mp.networkID
+		if resolvedNetworkID == "" {
+			var err error
+			resolvedNetworkID, err = ex.Network.NetworkIDFromName(ctx, mp.networkName)
+			if err != nil {
+				return nil, err
 			}
 		}
-		for _, id := range ex.Config.Spec.SubnetIDs {
-			if _, err := ex.Network.GetSubnet(ctx, id); err != nil {
-				return nil, fmt.Errorf("subnet [ID=%q] from SubnetIDs not found: %w", id, err)
+
+		if requiresPhysicalNetwork(mp.vnicType) {
+			if err := ex.checkPhysicalNetwork(ctx, resolvedNetworkID, mp.vnicType); err != nil {
+				return nil, err
 			}
 		}
-		portID, err := ex.getOrCreatePort(ctx, machineName)
+
+		portID, err := ex.getOrCreatePort(ctx, mp, resolvedNetworkID)
 		if err != nil {
 			return nil, err
 		}
+		serverNetworks = append(serverNetworks, servers.Network{UUID: resolvedNetworkID, Port: portID})
+	}
 
-		serverNetworks = append(serverNetworks, servers.Network{UUID: ex.Config.Spec.NetworkID, Port: portID})
-		return serverNetworks, nil
+	if ex.Config.Spec.Trunk != nil {
+		// the trunk has to exist before the server is created, so that its parent port is bound as trunk
+		if err := ex.ensureTrunk(ctx, machineName, managedPorts[0], serverNetworks[0].Port); err != nil {
+			return nil, err
+		}
 	}
+	return serverNetworks, nil
+}
 
-	if !isEmptyString(ptr.To(networkID)) {
-		klog.V(3).Infof("deploying in network [ID=%q]", networkID)
-		serverNetworks = append(serverNetworks, servers.Network{UUID: ex.Config.Spec.NetworkID})
-		return serverNetworks, nil
+// serverTimeout is the maximum duration of the build or the deletion of a server outside of bare-metal mode.
+const serverTimeout = 1200 * time.Second
+
+// defaultBareMetalTimeout is the default maximum duration of the deployment or the cleaning of a bare-metal node.
+const defaultBareMetalTimeout = time.Hour
+
+// defaultPollInterval is the default interval in which the status of servers, volumes and nodes is polled.
+const defaultPollInterval = 10 * time.Second
+
+// pollInterval returns the interval in which the status of servers, volumes and nodes is polled.
+func (ex *Executor) pollInterval() time.Duration {
+	if ex.PollInterval > 0 {
+		return ex.PollInterval
 	}
+	return defaultPollInterval
+}
 
-	for _, network := range networks {
-		var (
-			resolvedNetworkID string
-			err               error
-		)
-		if isEmptyString(ptr.To(network.Id)) {
-			resolvedNetworkID, err = ex.Network.NetworkIDFromName(ctx, network.Name)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			resolvedNetworkID = network.Id
+// serverBuildTimeout returns the maximum duration of the build of a server.
+func (ex *Executor) serverBuildTimeout() time.Duration {
+	if bareMetal := ex.Config.Spec.BareMetal; bareMetal != nil {
+		if bareMetal.DeployTimeout != nil {
+			return bareMetal.DeployTimeout.Duration
 		}
-		serverNetworks = append(serverNetworks, servers.Network{UUID: resolvedNetworkID})
+		return defaultBareMetalTimeout
 	}
-	return serverNetworks, nil
+	return serverTimeout
+}
+
+// serverDeleteTimeout returns the maximum duration of the deletion of a server. In bare-metal mode it also bounds the
+// cleaning of the node.
+func (ex *Executor) serverDeleteTimeout() time.Duration {
+	if bareMetal := ex.Config.Spec.BareMetal; bareMetal != nil {
+		if bareMetal.CleaningTimeout != nil {
+			return bareMetal.CleaningTimeout.Duration
+		}
+		return defaultBareMetalTimeout
+	}
+	return serverTimeout
 }
 
 // waitForServerStatus blocks until the server with the specified ID reaches one of the target status and returns the server after reaching this status.
 // waitForServerStatus will fail if an error occurs, the operation it timeouts after the specified time, or the server status is not in the pending list.
-func (ex *Executor) waitForServerStatus(ctx context.Context, serverID string, pending []string, target []string, secs int) (*servers.Server, error) {
+func (ex *Executor) waitForServerStatus(ctx context.Context, serverID string, pending []string, target []string, timeout time.Duration) (*servers.Server, error) {
 	var server *servers.Server
 	return server, wait.PollUntilContextTimeout(
 		ctx,
-		10*time.Second,
-		time.Duration(secs)*time.Second,
+		ex.pollInterval(),
+		timeout,
 		true,
 		func(_ context.Context) (done bool, err error) {
 			current, err := ex.Compute.GetServer(ctx, serverID)
@@ -252,17 +644,22 @@ func (ex *Executor) waitForServerStatus(ctx context.Context, serverID string, pe
 		})
 }
 
-// deployServer handles creating the server instance.
-func (ex *Executor) deployServer(ctx context.Context, machineName string, userData []byte, nws []servers.Network) (*servers.Server, error) {
+// deployServer handles creating the server instance in the given availability zone. The flavor candidates are tried in
+// order until a server could be created: a candidate is skipped if the flavor does not exist or, unless the server is
+// created asynchronously, if no valid host can be found for it. In the latter case the server is returned after it
+// became active.
+func (ex *Executor) deployServer(ctx context.Context, machineName string, userData []byte, nws []servers.Network, availabilityZone string) (*servers.Server, error) {
 	keyName := ex.Config.Spec.KeyName
 	imageName := ex.Config.Spec.ImageName
 	imageID := ex.Config.Spec.ImageID
 	securityGroups := ex.Config.Spec.SecurityGroups
-	availabilityZone := ex.Config.Spec.AvailabilityZone
 	metadata := ex.Config.Spec.Tags
 	rootDiskSize := ex.Config.Spec.RootDiskSize
 	useConfigDrive := ex.Config.Spec.UseConfigDrive
-	flavorName := ex.Config.Spec.FlavorName
+	if useConfigDrive == nil && ex.Config.Spec.BareMetal != nil {
+		// the metadata service is usually not reachable from the provisioning network of bare-metal nodes.
+		useConfigDrive = ptr.To(true)
+	}
 
 	var (
 		imageRef       string
@@ -270,117 +667,750 @@ func (ex *Executor) deployServer(ctx context.Context, machineName string, userDa
 		serverHintOpts servers.SchedulerHintOpts
 	)
 
-	// use imageID if provided, otherwise try to resolve the imageName to an imageID
-	if imageID != "" {
+	// use imageID if provided, otherwise try to resolve the imageSelector or the imageName to an imageID
+	switch {
+	case ex.Config.Spec.RootVolumeSource != nil:
+		// the server boots from a root volume which is not created from an image
+	case imageID != "":
 		imageRef = imageID
-	} else {
+	case ex.Config.Spec.ImageSelector != nil:
+		image, err := ex.selectImage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting image: %w", err)
+		}
+		klog.V(2).Infof("selected image [Name=%q, ID=%q] for machine [Name=%q]", image.Name, image.ID, machineName)
+		imageRef = image.ID
+
+		// record the selected image, as the image selector can match a different image later on
+		metadata = withServerMetadata(metadata, serverMetadataImageID, imageRef)
+	default:
 		image, err := ex.Compute.ImageIDFromName(ctx, imageName)
 		if err != nil {
 			return nil, fmt.Errorf("error resolving image ID from image name %q: %v", imageName, err)
 		}
 		imageRef = image.ID
 	}
-	flavorRef, err := ex.Compute.FlavorIDFromName(ctx, flavorName)
+	// record the availability zone the server was created in, if it can differ from the configured one
+	if len(ex.Config.Spec.FallbackAvailabilityZones) > 0 {
+		metadata = withServerMetadata(metadata, serverMetadataAvailabilityZone, availabilityZone)
+	}
+
+	capabilities := client.GetComputeCapabilities(ctx, ex.Compute)
+	if err := ex.checkComputeCapabilities(capabilities); err != nil {
+		return nil, err
+	}
+	hostname, err := ex.serverHostname(machineName)
 	if err != nil {
-		switch err.(type) {
-		case gophercloud.ErrResourceNotFound:
-			return nil, fmt.Errorf("error resolving flavor ID from flavor name %q: %w", flavorName, ErrFlavorNotFound{Flavor: flavorName})
-		default:
-			return nil, fmt.Errorf("error resolving flavor ID from flavor name %q: %v", flavorName, err)
-		}
+		return nil, err
+	}
+	serverFields, err := ex.serverFields(machineName)
+	if err != nil {
+		return nil, err
 	}
 
 	createOpts := &servers.CreateOpts{
-		Name:             machineName,
-		FlavorRef:        flavorRef,
-		ImageRef:         imageRef,
-		Networks:         nws,
-		SecurityGroups:   securityGroups,
-		Metadata:         metadata,
-		UserData:         userData,
-		AvailabilityZone: availabilityZone,
-		ConfigDrive:      useConfigDrive,
+		Name:               machineName,
+		ImageRef:           imageRef,
+		Networks:           nws,
+		SecurityGroups:     securityGroups,
+		Metadata:           metadata,
+		UserData:           userData,
+		AvailabilityZone:   availabilityZone,
+		ConfigDrive:        useConfigDrive,
+		HypervisorHostname: ex.Config.Spec.HypervisorHostname,
+		Hostname:           hostname,
 	}
 
-	if ex.Config.Spec.ServerGroupID != nil {
-		serverHintOpts = servers.SchedulerHintOpts{
-			Group: *ex.Config.Spec.ServerGroupID,
+	// The cluster and role tags are always stored as server metadata, so that servers can be identified on clouds without
+	// server tags support. If supported, they are set as server tags in addition to allow a server-side filtering.
+	if capabilities.ServerCreateTags {
+		createOpts.Tags, err = ex.managedTags()
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// If a custom block_device (root disk size is provided) we need to boot from volume
-	if rootDiskSize > 0 {
+	serverHintOpts, err = ex.schedulerHintOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If a custom block_device (root disk size or root volume source is provided) we need to boot from volume
+	if rootDiskSize > 0 || ex.Config.Spec.RootVolumeSource != nil {
 		createOpts, err = ex.addBlockDeviceOpts(ctx, machineName, imageRef, createOpts)
 		if err != nil {
 			return nil, fmt.Errorf("error adding block device opts %w", err)
 		}
 	}
 
+	if len(ex.Config.Spec.DataVolumes) > 0 {
+		createOpts, err = ex.addDataVolumeBlockDeviceOpts(ctx, machineName, imageRef, createOpts)
+		if err != nil {
+			return nil, fmt.Errorf("error adding data volume block device opts %w", err)
+		}
+	}
+
+	var serverCreateOpts servers.CreateOptsBuilder = createOpts
+	if len(ex.Config.Spec.BlockDevices) > 0 {
+		if deviceNames := ex.addLocalBlockDeviceOpts(imageRef, createOpts); len(deviceNames) > 0 {
+			serverCreateOpts = blockDeviceNamesOpts{
+				CreateOptsBuilder: createOpts,
+				deviceNames:       deviceNames,
+			}
+		}
+	}
+	if len(serverFields) > 0 {
+		serverCreateOpts = serverFieldsOpts{
+			CreateOptsBuilder: serverCreateOpts,
+			fields:            serverFields,
+		}
+	}
+
 	createOptsBuilder := &keypairs.CreateOptsExt{
-		CreateOptsBuilder: createOpts,
+		CreateOptsBuilder: serverCreateOpts,
 		KeyName:           keyName,
 	}
 
-	return ex.Compute.CreateServer(ctx, createOptsBuilder, serverHintOpts)
-}
+	candidates, err := ex.flavorCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: no flavor specified", ErrInvalidArgument)
+	}
 
-func (ex *Executor) addBlockDeviceOpts(ctx context.Context, machineName,
-	imageID string, createOpts *servers.CreateOpts) (*servers.CreateOpts, error) {
-	createOpts.BlockDevice = make([]servers.BlockDevice, 1)
+	var lastErr error
+	for index, candidate := range candidates {
+		hasNext := index < len(candidates)-1
 
-	if ex.Config.Spec.RootDiskType != nil {
-		volumeID, err := ex.ensureVolume(ctx, machineName, imageID, nil)
+		createOpts.FlavorRef, err = ex.resolveFlavor(ctx, candidate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to ensure volume [Name=%q]: %s", machineName, err)
+			if errors.As(err, &ErrFlavorNotFound{}) && hasNext {
+				klog.Warningf("skipping flavor [Name=%q] for server [Name=%q]: %v", candidate.name, machineName, err)
+				lastErr = err
+				continue
+			}
+			return nil, err
+		}
+		if len(ex.Config.Spec.BlockDevices) > 0 {
+			if err := ex.checkBlockDevices(ctx, createOpts.FlavorRef); err != nil {
+				if errors.Is(err, ErrInvalidArgument) && hasNext {
+					klog.Warningf("skipping flavor [Name=%q] for server [Name=%q]: %v", candidate.name, machineName, err)
+					lastErr = err
+					continue
+				}
+				return nil, err
+			}
 		}
 
-		createOpts.BlockDevice[0] = servers.BlockDevice{
-			UUID:                volumeID,
-			VolumeSize:          ex.Config.Spec.RootDiskSize,
-			BootIndex:           0,
-			DeleteOnTermination: false,
-			SourceType:          "volume",
-			DestinationType:     "volume",
+		server, err := ex.Compute.CreateServer(ctx, createOptsBuilder, serverHintOpts)
+		if err != nil {
+			if isNoValidHost(err) && hasNext {
+				klog.Warningf("no valid host found for server [Name=%q] with flavor [Name=%q], retrying with the next flavor", machineName, candidate.name)
+				lastErr = err
+				continue
+			}
+			return nil, err
 		}
-	} else {
-		createOpts.BlockDevice[0] = servers.BlockDevice{
-			UUID:                imageID,
-			VolumeSize:          ex.Config.Spec.RootDiskSize,
-			BootIndex:           0,
-			DeleteOnTermination: true,
-			SourceType:          "image",
-			DestinationType:     "volume",
+		if ex.createsServersAsync() {
+			return server, nil
 		}
-	}
 
-	klog.V(3).Infof("[DEBUG] Block Device Options: %+v", createOpts.BlockDevice[0])
+		activeServer, err := ex.waitForServerStatus(ctx,
+			server.ID,
+			[]string{client.ServerStatusBuild},
+			[]string{client.ServerStatusActive}, ex.serverBuildTimeout())
+		if err == nil {
+			return activeServer, nil
+		}
+		if !isNoValidHost(err) || !hasNext {
+			return nil, fmt.Errorf("error waiting for server [ID=%q] to reach target status: %w", server.ID, err)
+		}
 
-	return createOpts, nil
+		klog.Warningf("no valid host found for server [ID=%q] with flavor [Name=%q], retrying with the next flavor", server.ID, candidate.name)
+		lastErr = err
+		if err := ex.deleteServer(ctx, server.ID); err != nil {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+// flavorCandidate is a flavor a server can be created with. The ID is resolved from the name if it is not known yet.
+type flavorCandidate struct {
+	name string
+	id   string
+}
+
+// flavorCandidates returns the flavors to try in order, i.e. the flavors matching the flavor selector from the smallest
+// to the largest one, or the flavor given by FlavorName followed by the FlavorNames fallbacks.
+func (ex *Executor) flavorCandidates(ctx context.Context) ([]flavorCandidate, error) {
+	if ex.Config.Spec.FlavorSelector != nil {
+		return ex.selectFlavors(ctx)
+	}
+
+	var candidates []flavorCandidate
+	for _, name := range append([]string{ex.Config.Spec.FlavorName}, ex.Config.Spec.FlavorNames...) {
+		if name != "" {
+			candidates = append(candidates, flavorCandidate{name: name})
+		}
+	}
+	return candidates, nil
+}
+
+// resolveFlavor returns the ID of the flavor candidate.
+func (ex *Executor) resolveFlavor(ctx context.Context, candidate flavorCandidate) (string, error) {
+	if candidate.id != "" {
+		return candidate.id, nil
+	}
+
+	flavorRef, err := ex.Compute.FlavorIDFromName(ctx, candidate.name)
+	if err != nil {
+		switch err.(type) {
+		case gophercloud.ErrResourceNotFound:
+			return "", fmt.Errorf("error resolving flavor ID from flavor name %q: %w", candidate.name, ErrFlavorNotFound{Flavor: candidate.name})
+		default:
+			return "", fmt.Errorf("error resolving flavor ID from flavor name %q: %v", candidate.name, err)
+		}
+	}
+	return flavorRef, nil
+}
+
+// selectFlavors returns the flavors meeting the requirements of the flavor selector, ordered by vCPUs, memory, disk and
+// name, so that the smallest flavor is tried first.
+func (ex *Executor) selectFlavors(ctx context.Context) ([]flavorCandidate, error) {
+	selector := ex.Config.Spec.FlavorSelector
+	flavorList, err := ex.Compute.ListFlavors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing flavors: %w", err)
+	}
+
+	var matching []flavors.Flavor
+	for _, flavor := range flavorList {
+		if flavor.VCPUs < selector.MinVCPUs || flavor.RAM < selector.MinRAM || flavor.Disk < selector.MinDisk {
+			continue
+		}
+		if len(selector.ExtraSpecs) > 0 {
+			extraSpecs, err := ex.Compute.ListFlavorExtraSpecs(ctx, flavor.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error listing extra specs of flavor [Name=%q]: %w", flavor.Name, err)
+			}
+			if !hasExtraSpecs(extraSpecs, selector.ExtraSpecs) {
+				continue
+			}
+		}
+		matching = append(matching, flavor)
+	}
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("no flavor matches the flavor selector: %w", ErrFlavorNotFound{})
+	}
+
+	slices.SortFunc(matching, func(a, b flavors.Flavor) int {
+		return cmp.Or(
+			cmp.Compare(a.VCPUs, b.VCPUs),
+			cmp.Compare(a.RAM, b.RAM),
+			cmp.Compare(a.Disk, b.Disk),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+
+	candidates := make([]flavorCandidate, 0, len(matching))
+	for _, flavor := range matching {
+		candidates = append(candidates, flavorCandidate{name: flavor.Name, id: flavor.ID})
+	}
+	return candidates, nil
+}
+
+// schedulerHintOpts returns the scheduler hints for the server, i.e. the server group and the SchedulerHints of the spec.
+func (ex *Executor) schedulerHintOpts(ctx context.Context) (servers.SchedulerHintOpts, error) {
+	opts := servers.SchedulerHintOpts{
+		Group: ptr.Deref(ex.Config.Spec.ServerGroupID, ""),
+	}
+	if ex.Config.Spec.ServerGroup != nil {
+		serverGroupID, err := ex.ensureServerGroup(ctx)
+		if err != nil {
+			return opts, err
+		}
+		opts.Group = serverGroupID
+	}
+
+	hints := ex.Config.Spec.SchedulerHints
+	if hints == nil {
+		return opts, nil
+	}
+
+	opts.DifferentHost = hints.DifferentHost
+	opts.SameHost = hints.SameHost
+	opts.TargetCell = hints.TargetCell
+	opts.DifferentCell = hints.DifferentCell
+	opts.BuildNearHostIP = hints.BuildNearHostIP
+	if hints.Query != "" {
+		if err := json.Unmarshal([]byte(hints.Query), &opts.Query); err != nil {
+			return opts, fmt.Errorf("%w: scheduler hint query is not valid JSON: %v", ErrInvalidArgument, err)
+		}
+	}
+	return opts, nil
+}
+
+// ensureServerGroup returns the ID of the server group managed by MCM and creates it if it does not exist.
+func (ex *Executor) ensureServerGroup(ctx context.Context) (string, error) {
+	spec := ex.Config.Spec.ServerGroup
+	serverGroups, err := ex.listServerGroups(ctx, spec.Name)
+	if err != nil {
+		return "", err
+	}
+	if len(serverGroups) > 0 {
+		serverGroup := serverGroups[0]
+		if policy := serverGroupPolicy(serverGroup); policy != spec.Policy {
+			return "", fmt.Errorf("%w: server group [Name=%q, ID=%q] has policy %q instead of %q", ErrInvalidArgument, serverGroup.Name, serverGroup.ID, policy, spec.Policy)
+		}
+		return serverGroup.ID, nil
+	}
+
+	klog.V(3).Infof("creating server group [Name=%q, Policy=%q]", spec.Name, spec.Policy)
+	created, err := ex.Compute.CreateServerGroup(ctx, servergroups.CreateOpts{
+		Name:     spec.Name,
+		Policies: []string{spec.Policy},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating server group [Name=%q]: %w", spec.Name, err)
+	}
+
+	// Machines of the same class can be created concurrently and create the server group multiple times. All of them
+	// agree on the server group with the lowest ID, the others are removed again.
+	serverGroups, err = ex.listServerGroups(ctx, spec.Name)
+	if err != nil {
+		return "", err
+	}
+	if len(serverGroups) > 0 && serverGroups[0].ID != created.ID {
+		klog.V(3).Infof("server group [Name=%q] was created concurrently, deleting duplicate [ID=%q]", spec.Name, created.ID)
+		if err := ex.Compute.DeleteServerGroup(ctx, created.ID); err != nil {
+			return "", fmt.Errorf("error deleting duplicate server group [ID=%q]: %w", created.ID, err)
+		}
+		return serverGroups[0].ID, nil
+	}
+	return created.ID, nil
+}
+
+// releaseServerGroup deletes the server group managed by MCM once it has no members anymore. The members are taken from
+// the server group itself, as servers of the cluster and role are not necessarily tagged yet.
+func (ex *Executor) releaseServerGroup(ctx context.Context) error {
+	spec := ex.Config.Spec.ServerGroup
+	serverGroups, err := ex.listServerGroups(ctx, spec.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, serverGroup := range serverGroups {
+		if len(serverGroup.Members) > 0 {
+			klog.V(3).Infof("server group [Name=%q, ID=%q] is still in use", serverGroup.Name, serverGroup.ID)
+			continue
+		}
+		klog.V(2).Infof("deleting server group [Name=%q, ID=%q]", serverGroup.Name, serverGroup.ID)
+		if err := ex.Compute.DeleteServerGroup(ctx, serverGroup.ID); err != nil {
+			return fmt.Errorf("error deleting server group [ID=%q]: %w", serverGroup.ID, err)
+		}
+	}
+	return nil
+}
+
+// listServerGroups returns the server groups with the given name ordered by ID.
+func (ex *Executor) listServerGroups(ctx context.Context, name string) ([]servergroups.ServerGroup, error) {
+	allServerGroups, err := ex.Compute.ListServerGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing server groups: %w", err)
+	}
+
+	var result []servergroups.ServerGroup
+	for _, serverGroup := range allServerGroups {
+		if serverGroup.Name == name {
+			result = append(result, serverGroup)
+		}
+	}
+	slices.SortFunc(result, func(a, b servergroups.ServerGroup) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+func (ex *Executor) addBlockDeviceOpts(ctx context.Context, machineName,
+	imageID string, createOpts *servers.CreateOpts) (*servers.CreateOpts, error) {
+	createOpts.BlockDevice = make([]servers.BlockDevice, 1)
+	source := ex.Config.Spec.RootVolumeSource
+
+	switch {
+	case ex.managesRootVolume():
+		volumeOpts := volumes.CreateOpts{
+			Name:             machineName,
+			VolumeType:       ptr.Deref(ex.Config.Spec.RootDiskType, ""),
+			Size:             ex.Config.Spec.RootDiskSize,
+			ImageID:          imageID,
+			AvailabilityZone: ex.volumeAvailabilityZone(createOpts.AvailabilityZone),
+			Metadata:         ex.volumeMetadata(),
+		}
+		if source != nil {
+			var err error
+			volumeOpts.SnapshotID, volumeOpts.SourceVolID, err = ex.resolveRootVolumeSource(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		volumeID, err := ex.ensureVolume(ctx, volumeOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to ensure volume [Name=%q]: %s", machineName, err)
+		}
+
+		createOpts.BlockDevice[0] = servers.BlockDevice{
+			UUID:                volumeID,
+			VolumeSize:          ex.Config.Spec.RootDiskSize,
+			BootIndex:           0,
+			DeleteOnTermination: false,
+			SourceType:          "volume",
+			DestinationType:     "volume",
+		}
+	case source != nil:
+		// Nova creates the root volume from the snapshot and deletes it together with the server.
+		snapshotID, _, err := ex.resolveRootVolumeSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		createOpts.BlockDevice[0] = servers.BlockDevice{
+			UUID:                snapshotID,
+			VolumeSize:          ex.Config.Spec.RootDiskSize,
+			BootIndex:           0,
+			DeleteOnTermination: true,
+			SourceType:          "snapshot",
+			DestinationType:     "volume",
+		}
+	default:
+		createOpts.BlockDevice[0] = servers.BlockDevice{
+			UUID:                imageID,
+			VolumeSize:          ex.Config.Spec.RootDiskSize,
+			BootIndex:           0,
+			DeleteOnTermination: true,
+			SourceType:          "image",
+			DestinationType:     "volume",
+		}
+	}
+
+	klog.V(3).Infof("[DEBUG] Block Device Options: %+v", createOpts.BlockDevice[0])
+
+	return createOpts, nil
+}
+
+// managesRootVolume returns whether the root volume is created and deleted by MCM instead of Nova. This is the case if
+// a root disk type or a deletion policy other than Delete is given, or if the root volume is cloned from another
+// volume, which Nova does not support.
+func (ex *Executor) managesRootVolume() bool {
+	if ex.Config.Spec.RootDiskType != nil {
+		return true
+	}
+	if policy := ex.Config.Spec.RootVolumeDeletionPolicy; policy != "" && policy != cloudprovider.RootVolumeDeletionPolicyDelete {
+		return true
+	}
+	source := ex.Config.Spec.RootVolumeSource
+	return source != nil && (source.VolumeID != "" || source.VolumeName != "")
+}
+
+// resolveRootVolumeSource resolves the RootVolumeSource to the ID of the snapshot or the ID of the volume the root volume
+// is created from.
+func (ex *Executor) resolveRootVolumeSource(ctx context.Context) (snapshotID, volumeID string, err error) {
+	source := ex.Config.Spec.RootVolumeSource
+	switch {
+	case source.SnapshotID != "":
+		return source.SnapshotID, "", nil
+	case source.SnapshotName != "":
+		snapshotID, err = ex.Storage.SnapshotIDFromName(ctx, source.SnapshotName)
+		if err != nil {
+			return "", "", fmt.Errorf("error resolving snapshot ID from snapshot name %q: %w", source.SnapshotName, err)
+		}
+		return snapshotID, "", nil
+	case source.VolumeID != "":
+		return "", source.VolumeID, nil
+	default:
+		volumeID, err = ex.Storage.VolumeIDFromName(ctx, source.VolumeName)
+		if err != nil {
+			return "", "", fmt.Errorf("error resolving volume ID from volume name %q: %w", source.VolumeName, err)
+		}
+		return "", volumeID, nil
+	}
+}
+
+// addLocalBlockDeviceOpts adds the ephemeral and swap disks of the BlockDevices as local block devices. It returns the
+// requested device names by the index of the block device.
+func (ex *Executor) addLocalBlockDeviceOpts(imageID string, createOpts *servers.CreateOpts) map[int]string {
+	addImageBootDevice(imageID, createOpts)
+
+	deviceNames := map[int]string{}
+	for _, blockDevice := range ex.Config.Spec.BlockDevices {
+		guestFormat := blockDevice.GuestFormat
+		if blockDevice.Type == cloudprovider.BlockDeviceTypeSwap {
+			guestFormat = "swap"
+		}
+		if blockDevice.DeviceName != "" {
+			deviceNames[len(createOpts.BlockDevice)] = blockDevice.DeviceName
+		}
+		createOpts.BlockDevice = append(createOpts.BlockDevice, servers.BlockDevice{
+			BootIndex:           -1,
+			DeleteOnTermination: true,
+			SourceType:          "blank",
+			DestinationType:     "local",
+			GuestFormat:         guestFormat,
+			VolumeSize:          blockDevice.Size,
+		})
+	}
+
+	return deviceNames
+}
+
+// checkBlockDevices checks that the ephemeral and swap disks of the BlockDevices fit into the flavor with the given ID.
+func (ex *Executor) checkBlockDevices(ctx context.Context, flavorID string) error {
+	flavor, err := ex.Compute.GetFlavor(ctx, flavorID)
+	if err != nil {
+		return fmt.Errorf("error getting flavor [ID=%q]: %w", flavorID, err)
+	}
+
+	ephemeral := 0
+	for _, blockDevice := range ex.Config.Spec.BlockDevices {
+		switch blockDevice.Type {
+		case cloudprovider.BlockDeviceTypeSwap:
+			if flavor.Swap == 0 {
+				return fmt.Errorf("%w: flavor [Name=%q] has no swap space", ErrInvalidArgument, flavor.Name)
+			}
+			if blockDevice.Size > flavor.Swap {
+				return fmt.Errorf("%w: swap disk of %d MB exceeds the swap space of flavor [Name=%q] of %d MB", ErrInvalidArgument, blockDevice.Size, flavor.Name, flavor.Swap)
+			}
+		case cloudprovider.BlockDeviceTypeEphemeral:
+			if flavor.Ephemeral == 0 {
+				return fmt.Errorf("%w: flavor [Name=%q] has no ephemeral disk space", ErrInvalidArgument, flavor.Name)
+			}
+			ephemeral += cmp.Or(blockDevice.Size, flavor.Ephemeral)
+		}
+	}
+	if ephemeral > flavor.Ephemeral {
+		return fmt.Errorf("%w: ephemeral disks of %d GB exceed the ephemeral disk space of flavor [Name=%q] of %d GB", ErrInvalidArgument, ephemeral, flavor.Name, flavor.Ephemeral)
+	}
+	return nil
+}
+
+// checkComputeCapabilities returns an error wrapping ErrInvalidArgument if the provider spec requests a server feature,
+// which is not supported by the compute API of the cloud.
+func (ex *Executor) checkComputeCapabilities(capabilities client.ComputeCapabilities) error {
+	spec := ex.Config.Spec
+	if (spec.Host != "" || spec.HypervisorHostname != "") && !capabilities.HostPinning {
+		return fmt.Errorf("%w: pinning servers to a host requires compute API microversion %s", ErrInvalidArgument, client.MicroversionHostPinning)
+	}
+	if len(spec.TrustedImageCertificates) > 0 && !capabilities.TrustedImageCertificates {
+		return fmt.Errorf("%w: trusted image certificates require compute API microversion %s", ErrInvalidArgument, client.MicroversionTrustedImageCertificates)
+	}
+	if spec.Hostname != "" && !capabilities.Hostname {
+		return fmt.Errorf("%w: setting the hostname requires compute API microversion %s", ErrInvalidArgument, client.MicroversionServerHostname)
+	}
+	if spec.FQDN != "" && !capabilities.FQDN {
+		return fmt.Errorf("%w: setting a fully qualified domain name as hostname requires compute API microversion %s", ErrInvalidArgument, client.MicroversionServerFQDN)
+	}
+	if spec.Description != "" && !capabilities.Description {
+		return fmt.Errorf("%w: setting the description requires compute API microversion %s", ErrInvalidArgument, client.MicroversionServerDescription)
+	}
+	return nil
+}
+
+// renderTemplate renders a template of the provider spec for the machine with the supplied name. Rendering errors wrap
+// ErrInvalidArgument.
+func (ex *Executor) renderTemplate(name, text, machineName string) (string, error) {
+	rendered, err := renderServerTemplate(name, text, serverTemplateData{
+		MachineName:      machineName,
+		MachineClassName: ex.MachineClassName,
+		Region:           ex.Config.Spec.Region,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to render %s template: %v", ErrInvalidArgument, name, err)
+	}
+	return rendered, nil
+}
+
+// serverHostname returns the hostname of the server rendered from the hostname or FQDN template. It is empty if no
+// template is set, in which case Nova derives the hostname from the server name.
+func (ex *Executor) serverHostname(machineName string) (string, error) {
+	switch {
+	case ex.Config.Spec.FQDN != "":
+		return ex.renderTemplate("fqdn", ex.Config.Spec.FQDN, machineName)
+	case ex.Config.Spec.Hostname != "":
+		return ex.renderTemplate("hostname", ex.Config.Spec.Hostname, machineName)
+	}
+	return "", nil
+}
+
+// NodeName returns the name of the node of the machine with the supplied name. The node name is the hostname rendered
+// from the hostname template if set, and the machine name otherwise.
+func (ex *Executor) NodeName(machineName string) (string, error) {
+	if ex.Config.Spec.Hostname == "" {
+		return machineName, nil
+	}
+	return ex.renderTemplate("hostname", ex.Config.Spec.Hostname, machineName)
+}
+
+// serverFields returns the server fields of the provider spec, which are not supported by servers.CreateOpts, by their
+// JSON name.
+func (ex *Executor) serverFields(machineName string) (map[string]any, error) {
+	fields := map[string]any{}
+	if ex.Config.Spec.Host != "" {
+		fields["host"] = ex.Config.Spec.Host
+	}
+	if len(ex.Config.Spec.TrustedImageCertificates) > 0 {
+		fields["trusted_image_certificates"] = ex.Config.Spec.TrustedImageCertificates
+	}
+	if ex.Config.Spec.Description != "" {
+		description, err := ex.renderTemplate("description", ex.Config.Spec.Description, machineName)
+		if err != nil {
+			return nil, err
+		}
+		fields["description"] = description
+	}
+	return fields, nil
+}
+
+// serverFieldsOpts sets server fields, which are not supported by servers.CreateOpts.
+type serverFieldsOpts struct {
+	servers.CreateOptsBuilder
+	// fields are the values of the additional server fields by their JSON name.
+	fields map[string]any
+}
+
+// ToServerCreateMap adds the fields to the request body of the wrapped servers.CreateOptsBuilder.
+func (opts serverFieldsOpts) ToServerCreateMap() (map[string]any, error) {
+	base, err := opts.CreateOptsBuilder.ToServerCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	serverMap, ok := base["server"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("server not found in request body")
+	}
+	maps.Copy(serverMap, opts.fields)
+	return base, nil
+}
+
+// blockDeviceNamesOpts sets the device names of the block device mapping, which are not supported by servers.CreateOpts.
+type blockDeviceNamesOpts struct {
+	servers.CreateOptsBuilder
+	// deviceNames are the device names by the index of the block device.
+	deviceNames map[int]string
+}
+
+// ToServerCreateMap adds the device names to the request body of the wrapped servers.CreateOptsBuilder.
+func (opts blockDeviceNamesOpts) ToServerCreateMap() (map[string]any, error) {
+	base, err := opts.CreateOptsBuilder.ToServerCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	serverMap, _ := base["server"].(map[string]any)
+	blockDevices, _ := serverMap["block_device_mapping_v2"].([]any)
+	for index, deviceName := range opts.deviceNames {
+		if index >= len(blockDevices) {
+			return nil, fmt.Errorf("block device with index %d not found", index)
+		}
+		if blockDevice, ok := blockDevices[index].(map[string]any); ok {
+			blockDevice["device_name"] = deviceName
+		}
+	}
+	return base, nil
+}
+
+// addImageBootDevice adds the image as local boot device if the server does not boot from volume, since Nova requires an
+// explicit boot device once block devices are specified.
+func addImageBootDevice(imageID string, createOpts *servers.CreateOpts) {
+	if len(createOpts.BlockDevice) == 0 {
+		createOpts.BlockDevice = append(createOpts.BlockDevice, servers.BlockDevice{
+			UUID:                imageID,
+			BootIndex:           0,
+			DeleteOnTermination: true,
+			SourceType:          "image",
+			DestinationType:     "local",
+		})
+	}
+}
+
+// addDataVolumeBlockDeviceOpts creates the data volumes of the machine and attaches them as block devices.
+func (ex *Executor) addDataVolumeBlockDeviceOpts(ctx context.Context, machineName,
+	imageID string, createOpts *servers.CreateOpts) (*servers.CreateOpts, error) {
+	addImageBootDevice(imageID, createOpts)
+
+	for _, dataVolume := range ex.Config.Spec.DataVolumes {
+		name := dataVolumeName(machineName, dataVolume)
+		volumeID, err := ex.ensureVolume(ctx, volumes.CreateOpts{
+			Name:             name,
+			VolumeType:       ptr.Deref(dataVolume.Type, ""),
+			Size:             dataVolume.Size,
+			AvailabilityZone: ex.volumeAvailabilityZone(createOpts.AvailabilityZone),
+			Metadata:         ex.volumeMetadata(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to ensure data volume [Name=%q]: %s", name, err)
+		}
+
+		// Data volumes are deleted by DeleteMachine according to their DeleteOnTermination setting.
+		createOpts.BlockDevice = append(createOpts.BlockDevice, servers.BlockDevice{
+			UUID:                volumeID,
+			BootIndex:           -1,
+			DeleteOnTermination: false,
+			SourceType:          "volume",
+			DestinationType:     "volume",
+		})
+	}
+
+	return createOpts, nil
+}
+
+// volumeAvailabilityZone returns the availability zone the volumes of a server in the given availability zone are
+// created in.
+func (ex *Executor) volumeAvailabilityZone(serverAvailabilityZone string) string {
+	return cmp.Or(ex.Config.Spec.VolumeAvailabilityZone, serverAvailabilityZone)
+}
+
+// volumeMetadata returns the metadata of the volumes. The tags of the machine take precedence over the VolumeMetadata.
+func (ex *Executor) volumeMetadata() map[string]string {
+	if len(ex.Config.Spec.VolumeMetadata) == 0 {
+		return ex.Config.Spec.Tags
+	}
+
+	metadata := maps.Clone(ex.Config.Spec.VolumeMetadata)
+	maps.Copy(metadata, ex.Config.Spec.Tags)
+	return metadata
+}
+
+// volumeSchedulerHintOpts returns the Cinder scheduler hints of the VolumeSchedulerHints or nil if there are none.
+func (ex *Executor) volumeSchedulerHintOpts() volumes.SchedulerHintOptsBuilder {
+	hints := ex.Config.Spec.VolumeSchedulerHints
+	if hints == nil {
+		return nil
+	}
+	return volumes.SchedulerHintOpts{
+		DifferentHost:   hints.DifferentHost,
+		SameHost:        hints.SameHost,
+		LocalToInstance: hints.LocalToInstance,
+	}
 }
 
-func (ex *Executor) ensureVolume(ctx context.Context, name, imageID string,
-	hintOpts volumes.SchedulerHintOptsBuilder) (string, error) {
+func (ex *Executor) ensureVolume(ctx context.Context, opts volumes.CreateOpts) (string, error) {
 	var (
 		volumeID string
 		err      error
 	)
 
-	volumeID, err = ex.Storage.VolumeIDFromName(ctx, name)
+	volumeID, err = ex.Storage.VolumeIDFromName(ctx, opts.Name)
 	if err != nil && !client.IsNotFoundError(err) {
 		return "", err
 	}
 
 	if client.IsNotFoundError(err) {
-		volume, err := ex.Storage.CreateVolume(ctx, volumes.CreateOpts{
-			Name:             name,
-			VolumeType:       *ex.Config.Spec.RootDiskType,
-			Size:             ex.Config.Spec.RootDiskSize,
-			ImageID:          imageID,
-			AvailabilityZone: ex.Config.Spec.AvailabilityZone,
-			Metadata:         ex.Config.Spec.Tags,
-		}, hintOpts)
+		volume, err := ex.Storage.CreateVolume(ctx, opts, ex.volumeSchedulerHintOpts())
 		if err != nil {
-			return "", fmt.Errorf("failed to created volume [Name=%s]: %v", name, err)
+			return "", fmt.Errorf("failed to created volume [Name=%s]: %v", opts.Name, err)
 		}
 		volumeID = volume.ID
 	}
@@ -397,7 +1427,7 @@ func (ex *Executor) ensureVolume(ctx context.Context, name, imageID string,
 func (ex *Executor) waitForVolumeStatus(ctx context.Context, volumeID string, pending, target []string, secs int) error {
 	return wait.PollUntilContextTimeout(
 		ctx,
-		10*time.Second,
+		ex.pollInterval(),
 		time.Duration(secs)*time.Second,
 		true,
 		func(_ context.Context) (done bool, err error) {
@@ -427,58 +1457,184 @@ func (ex *Executor) waitForVolumeStatus(ctx context.Context, volumeID string, pe
 		})
 }
 
-// patchServerPortsForPodNetwork updates a server's ports with rules for whitelisting the pod network CIDR.
-func (ex *Executor) patchServerPortsForPodNetwork(ctx context.Context, serverID string) error {
+// listServerPorts lists the ports attached to the server with the specified ID.
+func (ex *Executor) listServerPorts(ctx context.Context, serverID string) ([]ports.Port, error) {
 	allPorts, err := ex.Network.ListPorts(ctx, &ports.ListOpts{
 		DeviceID: serverID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get ports: %v", err)
+		return nil, fmt.Errorf("failed to get ports: %v", err)
 	}
 
 	if len(allPorts) == 0 {
-		return fmt.Errorf("got an empty port list for server %q", serverID)
+		return nil, fmt.Errorf("got an empty port list for server %q", serverID)
 	}
+	return allPorts, nil
+}
 
-	podNetworkIDs, err := ex.resolveNetworkIDsForPodNetwork(ctx)
+// tagManagedPorts tags the server ports that are managed by MCM with the cluster and role tags, if they are not tagged yet.
+func (ex *Executor) tagManagedPorts(ctx context.Context, machineName string, serverPorts []ports.Port) error {
+	portTags, err := ex.managedTags()
 	if err != nil {
-		return fmt.Errorf("failed to resolve network IDs for the pod network %v", err)
+		return err
 	}
 
-	// coalesce all pod network CIDRs into a single slice.
-	podCIDRs := sets.NewString(ex.Config.Spec.PodNetworkCIDRs...)
-	if ex.Config.Spec.PodNetworkCidr != "" {
-		podCIDRs.Insert(ex.Config.Spec.PodNetworkCidr)
+	portNames := ex.managedPortNames(machineName)
+	for _, port := range serverPorts {
+		if !portNames.Has(port.Name) {
+			continue
+		}
+
+		missing := missingTags(port.Tags, portTags)
+		if len(missing) == 0 {
+			klog.V(3).Infof("port [ID=%q] is already tagged. Skipping update...", port.ID)
+			continue
+		}
+
+		if err := ex.Network.TagPort(ctx, port.ID, append(port.Tags, missing...)); err != nil {
+			return fmt.Errorf("failed to tag port [ID=%q]: %v", port.ID, err)
+		}
 	}
+	return nil
+}
 
-	for _, port := range allPorts {
-		// if the port is not part of the networks we care about, continue.
-		if !podNetworkIDs.Has(port.NetworkID) {
+// tagServer sets the cluster and role tags as Nova server tags, if the compute API supports server tags and the server is
+// not tagged yet. Nova does not allow to update the tags of a server that is still building, hence tagServer must only
+// be called for built servers.
+func (ex *Executor) tagServer(ctx context.Context, server *servers.Server) error {
+	if !client.GetComputeCapabilities(ctx, ex.Compute).ServerTags {
+		return nil
+	}
+
+	serverTags, err := ex.managedTags()
+	if err != nil {
+		return err
+	}
+
+	existing := ptr.Deref(server.Tags, nil)
+	missing := missingTags(existing, serverTags)
+	if len(missing) == 0 {
+		klog.V(3).Infof("server [ID=%q] is already tagged. Skipping update...", server.ID)
+		return nil
+	}
+
+	if err := ex.Compute.ReplaceServerTags(ctx, server.ID, append(existing, missing...)); err != nil {
+		return fmt.Errorf("failed to tag server [ID=%q]: %v", server.ID, err)
+	}
+	return nil
+}
+
+// managedTags returns the cluster and role tags marking the servers and ports that are managed by MCM.
+func (ex *Executor) managedTags() ([]string, error) {
+	searchClusterName, searchNodeRole, ok := findMandatoryTags(ex.Config.Spec.Tags)
+	if !ok {
+		klog.Warningf("operation can not proceed: cluster/role tags are missing")
+		return nil, fmt.Errorf("operation can not proceed: cluster/role tags are missing")
+	}
+	return []string{searchClusterName, searchNodeRole}, nil
+}
+
+// reconcileServerPortsAllowedAddressPairs reconciles the allowed address pairs of the server's ports with the desired
+// pairs. Each port is updated at most once and the update is guarded by the revision number of the port, so that
+// concurrent changes to the pairs are not overwritten. The pairs added by MCM are recorded in the port tags, which are
+// updated in serverPorts. Ports without port security are skipped, as they do not filter any traffic and do not support
+// allowed address pairs.
+func (ex *Executor) reconcileServerPortsAllowedAddressPairs(ctx context.Context, machineName string, serverPorts []ports.Port) error {
+	podNetworkIDs, err := ex.resolveNetworkIDsForAllowedAddressPairs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve network IDs for the pod network %v", err)
+	}
+
+	unsecuredPortNames := ex.unsecuredPortNames(machineName)
+	for i := range serverPorts {
+		port := &serverPorts[i]
+		if unsecuredPortNames.Has(port.Name) {
 			continue
 		}
 
-		for _, cidr := range podCIDRs.List() {
-			if err := func() error {
-				for _, pair := range port.AllowedAddressPairs {
-					if pair.IPAddress == cidr {
-						klog.V(3).Infof("port [ID=%q] already allows pod network CIDR range. Skipping update...", port.ID)
-						return nil
-					}
-				}
-				if err := ex.Network.UpdatePort(ctx, port.ID, ports.UpdateOpts{
-					AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: cidr}},
-				}); err != nil {
-					return fmt.Errorf("failed to update allowed address pair for port [ID=%q]: %v", port.ID, err)
+		pairs, recorded, changed := reconcileAllowedAddressPairs(*port, ex.desiredAllowedAddressPairs(machineName, *port, podNetworkIDs))
+		if changed {
+			updateOpts := ports.UpdateOpts{AllowedAddressPairs: &pairs}
+			// the revision number is only known if Neutron supports the standard attribute revisions
+			if port.RevisionNumber > 0 {
+				updateOpts.RevisionNumber = ptr.To(port.RevisionNumber)
+			}
+			if err := ex.Network.UpdatePort(ctx, port.ID, updateOpts); err != nil {
+				if client.IsPreconditionFailed(err) {
+					return fmt.Errorf("port [ID=%q] has been modified concurrently, the allowed address pairs have to be reconciled again: %v", port.ID, err)
 				}
-				return nil
-			}(); err != nil {
-				return err
+				return fmt.Errorf("failed to update allowed address pairs for port [ID=%q]: %v", port.ID, err)
 			}
+			port.AllowedAddressPairs = pairs
+		} else {
+			klog.V(3).Infof("port [ID=%q] already has the desired allowed address pairs. Skipping update...", port.ID)
+		}
+
+		tags := allowedAddressPairTags(port.Tags, recorded)
+		if sets.New(tags...).Equal(sets.New(port.Tags...)) {
+			continue
 		}
+		if err := ex.Network.TagPort(ctx, port.ID, tags); err != nil {
+			return fmt.Errorf("failed to record the allowed address pairs of port [ID=%q]: %v", port.ID, err)
+		}
+		port.Tags = tags
 	}
 	return nil
 }
 
+// desiredAllowedAddressPairs returns the allowed address pairs desired on the port. Ports in the pod network allow the
+// pod network CIDRs of the address families of the port and the AllowedAddressPairs of the spec, the ports managed by
+// MCM allow the AllowedAddressPairs of their network.
+func (ex *Executor) desiredAllowedAddressPairs(machineName string, port ports.Port, podNetworkIDs sets.Set[string]) []ports.AddressPair {
+	var desired []ports.AddressPair
+	if podNetworkIDs.Has(port.NetworkID) {
+		for _, cidr := range ex.podNetworkCIDRs() {
+			// the CIDR can only be routed to the port if the port has an address of the same family.
+			if hasFixedIPOfFamily(port, netutils.IsIPv6CIDRString(cidr)) {
+				desired = append(desired, ports.AddressPair{IPAddress: cidr})
+			}
+		}
+		desired = append(desired, addressPairs(ex.Config.Spec.AllowedAddressPairs)...)
+	}
+	for _, mp := range ex.managedPorts(machineName) {
+		if mp.name == port.Name {
+			desired = append(desired, mp.allowedAddressPairs...)
+		}
+	}
+	return desired
+}
+
+// podNetworkCIDRs coalesces all pod network CIDRs into a single sorted slice.
+func (ex *Executor) podNetworkCIDRs() []string {
+	podCIDRs := sets.NewString(ex.Config.Spec.PodNetworkCIDRs...)
+	if ex.Config.Spec.PodNetworkCidr != "" {
+		podCIDRs.Insert(ex.Config.Spec.PodNetworkCidr)
+	}
+	return podCIDRs.List()
+}
+
+// resolveNetworkIDsForAllowedAddressPairs resolves the pod networks whose ports allow the pod CIDR range as allowed
+// address pairs. In bare-metal mode networks without port security are skipped, as Neutron rejects allowed address pairs
+// on their ports.
+func (ex *Executor) resolveNetworkIDsForAllowedAddressPairs(ctx context.Context) (sets.Set[string], error) {
+	podNetworkIDs, err := ex.resolveNetworkIDsForPodNetwork(ctx)
+	if err != nil || ex.Config.Spec.BareMetal == nil {
+		return podNetworkIDs, err
+	}
+
+	for _, networkID := range sets.List(podNetworkIDs) {
+		portSecurity, err := ex.Network.GetNetworkPortSecurity(ctx, networkID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching port security of network [ID=%q]: %w", networkID, err)
+		}
+		if !portSecurity {
+			klog.V(3).Infof("port security is disabled on network [ID=%q], skipping allowed address pairs", networkID)
+			podNetworkIDs.Delete(networkID)
+		}
+	}
+	return podNetworkIDs, nil
+}
+
 // resolveNetworkIDsForPodNetwork resolves the networks that accept traffic from the pod CIDR range.
 func (ex *Executor) resolveNetworkIDsForPodNetwork(ctx context.Context) (sets.Set[string], error) {
 	var (
@@ -512,69 +1668,326 @@ func (ex *Executor) resolveNetworkIDsForPodNetwork(ctx context.Context) (sets.Se
 	return podNetworkIDs, nil
 }
 
+// primaryPort returns the port of the server floating IPs are associated with. This is the first port managed by MCM if
+// there is one, the port in the network specified by NetworkID otherwise, or the first port as fallback.
+func (ex *Executor) primaryPort(machineName string, serverPorts []ports.Port) ports.Port {
+	for _, mp := range ex.managedPorts(machineName) {
+		for _, port := range serverPorts {
+			if port.Name == mp.name {
+				return port
+			}
+		}
+	}
+	for _, port := range serverPorts {
+		if port.NetworkID == ex.Config.Spec.NetworkID {
+			return port
+		}
+	}
+	return serverPorts[0]
+}
+
+// ensureFloatingIP associates a floating IP with the primary port of the server and returns the floating IP address. A
+// floating IP that is already associated with the port is reused. If a pool of floating IP addresses is configured, a
+// free floating IP of the pool is associated, otherwise a new floating IP is allocated with the machine name as
+// description and the cluster and role tags, which mark it as owned by MCM.
+func (ex *Executor) ensureFloatingIP(ctx context.Context, machineName string, serverPorts []ports.Port) (string, error) {
+	fipConfig := ex.Config.Spec.FloatingIP
+	port := ex.primaryPort(machineName, serverPorts)
+	if len(port.FixedIPs) > 0 && !hasFixedIPOfFamily(port, false) {
+		return "", fmt.Errorf("%w: floating IPs require an IPv4 address, but port [ID=%q] has IPv6 addresses only", ErrInvalidArgument, port.ID)
+	}
+
+	associated, err := ex.Network.ListFloatingIPs(ctx, floatingips.ListOpts{PortID: port.ID})
+	if err != nil {
+		return "", fmt.Errorf("failed to list floating IPs: %v", err)
+	}
+	if len(associated) > 0 {
+		klog.V(3).Infof("floating IP %q is already associated with port [ID=%q]", associated[0].FloatingIP, port.ID)
+		return associated[0].FloatingIP, nil
+	}
+
+	listOpts, err := ex.floatingIPListOpts(ctx, machineName)
+	if err != nil {
+		return "", err
+	}
+	candidates, err := ex.Network.ListFloatingIPs(ctx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list floating IPs: %v", err)
+	}
+
+	// reuse a free floating IP of the pool, or a floating IP that was allocated for the machine before
+	for _, fip := range candidates {
+		if fip.PortID != "" || (len(fipConfig.Pool) > 0 && !strSliceContains(fipConfig.Pool, fip.FloatingIP)) {
+			continue
+		}
+		klog.V(3).Infof("associating floating IP %q with port [ID=%q]", fip.FloatingIP, port.ID)
+		if err := ex.Network.UpdateFloatingIP(ctx, fip.ID, floatingips.UpdateOpts{PortID: ptr.To(port.ID)}); err != nil {
+			return "", fmt.Errorf("failed to associate floating IP %q: %v", fip.FloatingIP, err)
+		}
+		return fip.FloatingIP, nil
+	}
+
+	if len(fipConfig.Pool) > 0 {
+		return "", fmt.Errorf("no free floating IP found in pool %v", fipConfig.Pool)
+	}
+
+	klog.V(3).Infof("allocating floating IP for port [ID=%q]", port.ID)
+	fip, err := ex.Network.CreateFloatingIP(ctx, floatingips.CreateOpts{
+		Description:       machineName,
+		FloatingNetworkID: listOpts.FloatingNetworkID,
+		SubnetID:          ptr.Deref(fipConfig.SubnetID, ""),
+		PortID:            port.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate floating IP: %v", err)
+	}
+	// an untagged floating IP would never be released, hence it is released right away if it can not be tagged
+	if err := ex.Network.TagFloatingIP(ctx, fip.ID, strings.Split(listOpts.Tags, ",")); err != nil {
+		if errIn := ex.Network.DeleteFloatingIP(ctx, fip.ID); errIn != nil {
+			klog.Errorf("failed to release untagged floating IP [ID=%q]: %s", fip.ID, errIn)
+		}
+		return "", fmt.Errorf("failed to tag floating IP %q: %v", fip.FloatingIP, err)
+	}
+	return fip.FloatingIP, nil
+}
+
+// floatingIPListOpts returns the options to list the floating IP candidates of the machine on the floating network. If
+// no pool is configured, only the floating IPs allocated by MCM for the machine are listed.
+func (ex *Executor) floatingIPListOpts(ctx context.Context, machineName string) (floatingips.ListOpts, error) {
+	fipConfig := ex.Config.Spec.FloatingIP
+	networkID := fipConfig.NetworkID
+	if networkID == "" {
+		var err error
+		networkID, err = ex.Network.NetworkIDFromName(ctx, fipConfig.NetworkName)
+		if err != nil {
+			return floatingips.ListOpts{}, err
+		}
+	}
+
+	listOpts := floatingips.ListOpts{FloatingNetworkID: networkID}
+	if len(fipConfig.Pool) == 0 {
+		fipTags, err := ex.managedTags()
+		if err != nil {
+			return floatingips.ListOpts{}, err
+		}
+		listOpts.Description = machineName
+		listOpts.Tags = strings.Join(fipTags, ",")
+	}
+	return listOpts, nil
+}
+
+// releaseFloatingIPs releases the floating IPs allocated by MCM for the machine. Floating IPs of a pool are not released.
+func (ex *Executor) releaseFloatingIPs(ctx context.Context, machineName string) error {
+	if ex.Config.Spec.FloatingIP == nil || len(ex.Config.Spec.FloatingIP.Pool) > 0 {
+		return nil
+	}
+
+	listOpts, err := ex.floatingIPListOpts(ctx, machineName)
+	if err != nil {
+		return fmt.Errorf("error releasing floating IPs of machine [Name=%q]: %w", machineName, err)
+	}
+	fips, err := ex.Network.ListFloatingIPs(ctx, listOpts)
+	if err != nil {
+		return fmt.Errorf("error releasing floating IPs of machine [Name=%q]: %s", machineName, err)
+	}
+
+	for _, fip := range fips {
+		klog.V(2).Infof("releasing floating IP [ID=%q]", fip.ID)
+		if err := ex.Network.DeleteFloatingIP(ctx, fip.ID); err != nil {
+			klog.Errorf("failed to release floating IP [ID=%q]: %s", fip.ID, err)
+			return err
+		}
+	}
+	return nil
+}
+
 // DeleteMachine deletes a server based on the supplied machineName. If a providerID is supplied it is used instead of the
 // machineName to locate the server.
 func (ex *Executor) DeleteMachine(ctx context.Context, machineName, providerID string) error {
-	var (
-		server *servers.Server
-		err    error
-	)
+	server, err := ex.getMachine(ctx, machineName, providerID)
+	if err == nil {
+		klog.V(1).Infof("deleting server [Name=%s, ID=%s]", server.Name, server.ID)
+		if err := ex.deleteServer(ctx, server.ID); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
 
-	if !isEmptyString(ptr.To(providerID)) {
-		serverID := decodeProviderID(providerID)
-		server, err = ex.getMachineByID(ctx, serverID)
-	} else {
-		server, err = ex.getMachineByName(ctx, machineName)
+	if err := ex.releaseFloatingIPs(ctx, machineName); err != nil {
+		return err
 	}
 
-	if err == nil {
-		klog.V(1).Infof("deleting server [Name=%s, ID=%s]", server.Name, server.ID)
-		if err := ex.Compute.DeleteServer(ctx, server.ID); err != nil {
+	if err := ex.deletePorts(ctx, machineName); err != nil {
+		return err
+	}
+
+	if ex.Config.Spec.ServerGroup != nil {
+		if err := ex.releaseServerGroup(ctx); err != nil {
+			return err
+		}
+	}
+
+	if options := ex.Config.Spec.UserDataOptions; options != nil && options.SwiftContainer != "" {
+		if err := ex.ObjectStorage.DeleteObject(ctx, options.SwiftContainer, machineName); err != nil && !client.IsNotFoundError(err) {
+			return fmt.Errorf("error deleting staged user data [Name=%q]: %w", machineName, err)
+		}
+	}
+
+	return ex.deleteVolumes(ctx, machineName)
+}
+
+// deleteVolumes deletes the root volume managed by MCM and the data volumes of the machine according to their deletion
+// policies. The deletion of all volumes is started before an error wrapping ErrDeletionPending is returned, so that
+// the volumes are deleted in parallel and the deletion is confirmed by the next call.
+func (ex *Executor) deleteVolumes(ctx context.Context, machineName string) error {
+	var pending []error
+	collect := func(err error) error {
+		if errors.Is(err, ErrDeletionPending) {
+			pending = append(pending, err)
+			return nil
+		}
+		return err
+	}
+
+	if ex.managesRootVolume() {
+		if err := collect(ex.deleteRootVolume(ctx, machineName)); err != nil {
+			return err
+		}
+	}
+
+	for _, dataVolume := range ex.Config.Spec.DataVolumes {
+		if !ptr.Deref(dataVolume.DeleteOnTermination, true) {
+			klog.V(2).Infof("retaining data volume [Name=%q]", dataVolumeName(machineName, dataVolume))
+			continue
+		}
+		if err := collect(ex.deleteVolume(ctx, dataVolumeName(machineName, dataVolume))); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(pending...)
+}
+
+// deletePorts deletes the trunk and the ports managed by MCM for the machine.
+func (ex *Executor) deletePorts(ctx context.Context, machineName string) error {
+	if ex.Config.Spec.Trunk != nil {
+		// subports and the parent port can only be deleted after the trunk is gone
+		if err := ex.deleteTrunk(ctx, machineName); err != nil {
+			return err
+		}
+		for _, subPort := range ex.Config.Spec.Trunk.SubPorts {
+			if err := ex.deletePort(ctx, subPortName(machineName, subPort)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, mp := range ex.managedPorts(machineName) {
+		if err := ex.deletePort(ctx, mp.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteServer deletes the server with the supplied ID and waits until it is gone. In bare-metal mode it additionally
+// waits until the node of the server has been cleaned and released.
+func (ex *Executor) deleteServer(ctx context.Context, serverID string) error {
+	var (
+		node    *nodes.Node
+		pending []string
+		err     error
+	)
+	if ex.Config.Spec.BareMetal != nil {
+		if node, err = ex.findBareMetalNode(ctx, serverID); err != nil {
 			return err
 		}
+		// Ironic servers keep their status while the node is torn down. A server in ERROR signals a failed tear down,
+		// which is retried by deleting the server again instead of waiting for the timeout.
+		pending = []string{client.ServerStatusActive, client.ServerStatusBuild, client.ServerStatusShutoff}
+	}
 
-		if _, err = ex.waitForServerStatus(ctx, server.ID, nil, []string{client.ServerStatusDeleted}, 1200); err != nil {
-			return fmt.Errorf("error while waiting for server [ID=%q] to be deleted: %v", server.ID, err)
-		}
-	} else if !errors.Is(err, ErrNotFound) {
+	if err := ex.Compute.DeleteServer(ctx, serverID); err != nil {
 		return err
 	}
 
-	if ex.isUserManagedNetwork() {
-		err := ex.deletePort(ctx, machineName)
-		if err != nil {
-			return err
+	if _, err := ex.waitForServerStatus(ctx, serverID, pending, []string{client.ServerStatusDeleted}, ex.serverDeleteTimeout()); err != nil {
+		return fmt.Errorf("error while waiting for server [ID=%q] to be deleted: %v", serverID, err)
+	}
+
+	if node != nil {
+		if err := ex.waitForBareMetalNodeRelease(ctx, node.UUID, serverID); err != nil {
+			return fmt.Errorf("error while waiting for node [ID=%q] of server [ID=%q] to be released: %w", node.UUID, serverID, err)
 		}
 	}
+	return nil
+}
 
-	if ex.Config.Spec.RootDiskType != nil {
-		return ex.deleteVolume(ctx, machineName)
+// findBareMetalNode returns the Ironic node the server with the supplied ID is deployed on. Nil is returned if the server
+// has no node or if the nodes are not visible to the project.
+func (ex *Executor) findBareMetalNode(ctx context.Context, serverID string) (*nodes.Node, error) {
+	nodeList, err := ex.Baremetal.ListNodes(ctx, nodes.ListOpts{InstanceUUID: serverID})
+	if err != nil {
+		if client.IsForbidden(err) {
+			klog.Warningf("nodes are not visible to the project, not waiting for the node of server [ID=%q] to be released", serverID)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error fetching node of server [ID=%q]: %w", serverID, err)
+	}
+	if len(nodeList) == 0 {
+		return nil, nil
 	}
+	return &nodeList[0], nil
+}
 
-	return nil
+// waitForBareMetalNodeRelease blocks until the node with the supplied ID has been cleaned after the deletion of the server
+// with the supplied ID, i.e. until it is available for new deployments again or has been deployed for another server.
+func (ex *Executor) waitForBareMetalNodeRelease(ctx context.Context, nodeID, serverID string) error {
+	return wait.PollUntilContextTimeout(ctx, ex.pollInterval(), ex.serverDeleteTimeout(), true, func(_ context.Context) (bool, error) {
+		node, err := ex.Baremetal.GetNode(ctx, nodeID)
+		if err != nil {
+			if client.IsNotFoundError(err) {
+				return true, nil
+			}
+			return false, err
+		}
+
+		klog.V(3).Infof("waiting for node [ID=%q] with current provision state %q to be released", nodeID, node.ProvisionState)
+		if node.InstanceUUID != "" && node.InstanceUUID != serverID {
+			return true, nil
+		}
+		switch nodes.ProvisionState(node.ProvisionState) {
+		case nodes.Available, nodes.Manageable, nodes.Enroll:
+			return true, nil
+		case nodes.CleanFail, nodes.Error:
+			return false, fmt.Errorf("node [ID=%q] reached unexpected provision state %q: %s", nodeID, node.ProvisionState, node.LastError)
+		}
+		return false, nil
+	})
 }
 
-func (ex *Executor) getOrCreatePort(ctx context.Context, machineName string) (string, error) {
+func (ex *Executor) getOrCreatePort(ctx context.Context, mp managedPort, networkID string) (string, error) {
 	var (
 		err              error
 		securityGroupIDs []string
 	)
 
-	portID, err := ex.Network.PortIDFromName(ctx, machineName)
+	portID, err := ex.Network.PortIDFromName(ctx, mp.name)
 	if err == nil {
-		klog.V(2).Infof("found port [Name=%q, ID=%q]... skipping creation", machineName, portID)
+		klog.V(2).Infof("found port [Name=%q, ID=%q]... skipping creation", mp.name, portID)
 		return portID, nil
 	}
 
 	if !client.IsNotFoundError(err) {
-		klog.V(5).Infof("error fetching port [Name=%q]: %s", machineName, err)
-		return "", fmt.Errorf("error fetching port [Name=%q]: %s", machineName, err)
+		klog.V(5).Infof("error fetching port [Name=%q]: %s", mp.name, err)
+		return "", fmt.Errorf("error fetching port [Name=%q]: %s", mp.name, err)
 	}
 
-	klog.V(5).Infof("port [Name=%q] does not exist", machineName)
-	klog.V(3).Infof("creating port [Name=%q]... ", machineName)
+	klog.V(5).Infof("port [Name=%q] does not exist", mp.name)
+	klog.V(3).Infof("creating port [Name=%q]... ", mp.name)
 
-	for _, securityGroup := range ex.Config.Spec.SecurityGroups {
+	for _, securityGroup := range mp.securityGroups {
 		securityGroupID, err := ex.Network.GroupIDFromName(ctx, securityGroup)
 		if err != nil {
 			return "", err
@@ -582,24 +1995,39 @@ func (ex *Executor) getOrCreatePort(ctx context.Context, machineName string) (st
 		securityGroupIDs = append(securityGroupIDs, securityGroupID)
 	}
 
-	port, err := ex.Network.CreatePort(ctx, &ports.CreateOpts{
-		Name:           machineName,
-		NetworkID:      ex.Config.Spec.NetworkID,
-		FixedIPs:       ex.buildFixedIPs(),
+	portOpts := &ports.CreateOpts{
+		Name:           mp.name,
+		NetworkID:      networkID,
 		SecurityGroups: &securityGroupIDs,
-	})
-	if err != nil {
-		return "", err
+	}
+	// only request fixed IPs if any are specified, as an empty list would create the port without IP addresses
+	if len(mp.fixedIPs) > 0 {
+		portOpts.FixedIPs = mp.fixedIPs
 	}
 
-	searchClusterName, searchNodeRole, ok := findMandatoryTags(ex.Config.Spec.Tags)
-	if !ok {
-		klog.Warningf("operation can not proceed: cluster/role tags are missing")
-		return "", fmt.Errorf("operation can not proceed: cluster/role tags are missing")
+	var createOpts ports.CreateOptsBuilder = portOpts
+	if mp.disablePortSecurity {
+		createOpts = portsecurity.PortCreateOptsExt{
+			CreateOptsBuilder:   createOpts,
+			PortSecurityEnabled: ptr.To(false),
+		}
+	}
+	if mp.vnicType != "" || len(mp.bindingProfile) > 0 {
+		bindingOpts := portsbinding.CreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			VNICType:          mp.vnicType,
+		}
+		if len(mp.bindingProfile) > 0 {
+			bindingOpts.Profile = make(map[string]any, len(mp.bindingProfile))
+			for key, value := range mp.bindingProfile {
+				bindingOpts.Profile[key] = value
+			}
+		}
+		createOpts = bindingOpts
 	}
 
-	portTags := []string{searchClusterName, searchNodeRole}
-	if err := ex.Network.TagPort(ctx, port.ID, portTags); err != nil {
+	port, err := ex.Network.CreatePort(ctx, createOpts)
+	if err != nil {
 		return "", err
 	}
 
@@ -607,6 +2035,29 @@ func (ex *Executor) getOrCreatePort(ctx context.Context, machineName string) (st
 	return port.ID, nil
 }
 
+// checkPhysicalNetwork verifies that ports with the given vNIC type can be bound in the network with the specified ID,
+// i.e. that the network or one of its segments is mapped to a physical network. The check is skipped if the provider
+// attributes of the network are not visible to the user.
+func (ex *Executor) checkPhysicalNetwork(ctx context.Context, networkID, vnicType string) error {
+	attributes, err := ex.Network.GetNetworkProviderAttributes(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("error fetching provider attributes of network [ID=%q]: %w", networkID, err)
+	}
+	if attributes.NetworkType == "" && len(attributes.Segments) == 0 {
+		klog.V(3).Infof("provider attributes of network [ID=%q] are not visible, skipping physical network check", networkID)
+		return nil
+	}
+	if attributes.PhysicalNetwork != "" {
+		return nil
+	}
+	for _, segment := range attributes.Segments {
+		if segment.PhysicalNetwork != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: ports with vNIC type %q require a physical network, but network [ID=%q] has none", ErrInvalidArgument, vnicType, networkID)
+}
+
 // buildFixedIPs creates a list of FixedIPs from SubnetID and SubnetIDs, avoiding duplicates
 func (ex *Executor) buildFixedIPs() []ports.IP {
 	// Use a set to track unique subnet IDs and avoid duplicates
@@ -633,19 +2084,19 @@ func (ex *Executor) buildFixedIPs() []ports.IP {
 	return fixedIPs
 }
 
-func (ex *Executor) deletePort(ctx context.Context, machineName string) error {
+func (ex *Executor) deletePort(ctx context.Context, portName string) error {
 	portList, err := ex.Network.ListPorts(ctx, ports.ListOpts{
-		Name: machineName,
+		Name: portName,
 	})
 	if err != nil {
-		return fmt.Errorf("error deleting port [Name=%q]: %s", machineName, err)
+		return fmt.Errorf("error deleting port [Name=%q]: %s", portName, err)
 	}
 	if len(portList) == 0 {
-		klog.V(2).Infof("port [Name=%q] was not found", machineName)
+		klog.V(2).Infof("port [Name=%q] was not found", portName)
 		return nil
 	}
 
-	klog.V(2).Infof("deleting ports for machine [Name=%q]", machineName)
+	klog.V(2).Infof("deleting ports [Name=%q]", portName)
 	for _, p := range portList {
 		klog.V(2).Infof("deleting port [ID=%q]", p.ID)
 		err = ex.Network.DeletePort(ctx, p.ID)
@@ -659,22 +2110,256 @@ func (ex *Executor) deletePort(ctx context.Context, machineName string) error {
 	return nil
 }
 
-func (ex *Executor) deleteVolume(ctx context.Context, machineName string) error {
-	volumeID, err := ex.Storage.VolumeIDFromName(ctx, machineName)
+// selectImage returns the most recently created active image matching the image selector of the spec. Images created at
+// the same time are ordered by ID to select an image deterministically.
+func (ex *Executor) selectImage(ctx context.Context) (*images.Image, error) {
+	selector := ex.Config.Spec.ImageSelector
+	imageList, err := ex.Image.ListImages(ctx, images.ListOpts{
+		Name:       selector.Name,
+		Owner:      selector.Owner,
+		Visibility: images.ImageVisibility(selector.Visibility),
+		Tags:       selector.Tags,
+		Status:     images.ImageStatusActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []images.Image
+	for _, image := range imageList {
+		if image.Status == images.ImageStatusActive && hasImageProperties(image, selector.Properties) {
+			candidates = append(candidates, image)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no active image matches the image selector")
+	}
+
+	slices.SortFunc(candidates, func(a, b images.Image) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return &candidates[0], nil
+}
+
+// ensureTrunk makes sure that the parent port with the supplied ID is a trunk carrying all subports of the spec. The
+// subports are created in the same way as the parent port, i.e. with its security groups.
+func (ex *Executor) ensureTrunk(ctx context.Context, machineName string, parent managedPort, parentPortID string) error {
+	var subPorts []trunks.Subport
+	for _, subPort := range ex.Config.Spec.Trunk.SubPorts {
+		networkID := subPort.NetworkID
+		if networkID == "" {
+			var err error
+			networkID, err = ex.Network.NetworkIDFromName(ctx, subPort.NetworkName)
+			if err != nil {
+				return err
+			}
+		}
+
+		portID, err := ex.getOrCreatePort(ctx, managedPort{
+			name:                subPortName(machineName, subPort),
+			networkID:           networkID,
+			securityGroups:      parent.securityGroups,
+			disablePortSecurity: parent.disablePortSecurity,
+		}, networkID)
+		if err != nil {
+			return err
+		}
+		subPorts = append(subPorts, trunks.Subport{
+			PortID:           portID,
+			SegmentationType: segmentationTypeVLAN,
+			SegmentationID:   subPort.SegmentationID,
+		})
+	}
+
+	trunkList, err := ex.Network.ListTrunks(ctx, trunks.ListOpts{PortID: parentPortID})
+	if err != nil {
+		return fmt.Errorf("error listing trunks of port [ID=%q]: %w", parentPortID, err)
+	}
+	if len(trunkList) == 0 {
+		klog.V(3).Infof("creating trunk [Name=%q] for port [ID=%q]", machineName, parentPortID)
+		if _, err := ex.Network.CreateTrunk(ctx, trunks.CreateOpts{
+			Name:     machineName,
+			PortID:   parentPortID,
+			Subports: subPorts,
+		}); err != nil {
+			return fmt.Errorf("error creating trunk [Name=%q]: %w", machineName, err)
+		}
+		return nil
+	}
+
+	trunk := trunkList[0]
+	existing := sets.New[string]()
+	for _, subPort := range trunk.Subports {
+		existing.Insert(subPort.PortID)
+	}
+	var missing []trunks.Subport
+	for _, subPort := range subPorts {
+		if !existing.Has(subPort.PortID) {
+			missing = append(missing, subPort)
+		}
+	}
+	if len(missing) == 0 {
+		klog.V(2).Infof("found trunk [Name=%q, ID=%q]... skipping creation", trunk.Name, trunk.ID)
+		return nil
+	}
+
+	klog.V(3).Infof("adding %d subports to trunk [ID=%q]", len(missing), trunk.ID)
+	if err := ex.Network.AddSubports(ctx, trunk.ID, trunks.AddSubportsOpts{Subports: missing}); err != nil {
+		return fmt.Errorf("error adding subports to trunk [ID=%q]: %w", trunk.ID, err)
+	}
+	return nil
+}
+
+// deleteTrunk deletes the trunks created for the machine.
+func (ex *Executor) deleteTrunk(ctx context.Context, machineName string) error {
+	trunkList, err := ex.Network.ListTrunks(ctx, trunks.ListOpts{Name: machineName})
+	if err != nil {
+		return fmt.Errorf("error deleting trunk [Name=%q]: %w", machineName, err)
+	}
+
+	for _, trunk := range trunkList {
+		klog.V(2).Infof("deleting trunk [ID=%q]", trunk.ID)
+		if err := ex.Network.DeleteTrunk(ctx, trunk.ID); err != nil {
+			return fmt.Errorf("error deleting trunk [ID=%q]: %w", trunk.ID, err)
+		}
+	}
+	return nil
+}
+
+// deleteRootVolume handles the root volume of a deleted machine according to the RootVolumeDeletionPolicy.
+func (ex *Executor) deleteRootVolume(ctx context.Context, machineName string) error {
+	switch ex.Config.Spec.RootVolumeDeletionPolicy {
+	case cloudprovider.RootVolumeDeletionPolicyRetain:
+		klog.V(2).Infof("retaining root volume [Name=%q]", machineName)
+		return nil
+	case cloudprovider.RootVolumeDeletionPolicyBackup:
+		if err := ex.backupVolume(ctx, machineName); err != nil {
+			return err
+		}
+	}
+	return ex.deleteVolume(ctx, machineName)
+}
+
+// backupVolume creates a backup of the volume with the given name. It does not wait for the volume to be detached or
+// for the backup to become available, but returns an ErrVolumeNotDeleted wrapping ErrDeletionPending instead, so that the
+// backup is resumed by the next call. An existing backup of the volume is reused.
+func (ex *Executor) backupVolume(ctx context.Context, name string) error {
+	volumeID, err := ex.Storage.VolumeIDFromName(ctx, name)
 	if err != nil {
 		if client.IsNotFoundError(err) {
 			return nil
 		}
-		return fmt.Errorf("error deleting [Name=%q]: %s", machineName, err)
+		return fmt.Errorf("error backing up volume [Name=%q]: %s", name, err)
 	}
 
-	klog.V(2).Infof("deleting volume [Name=%q]", machineName)
-	err = ex.Storage.DeleteVolume(ctx, volumeID)
+	existing, err := ex.Storage.ListBackups(ctx, backups.ListOpts{VolumeID: volumeID})
+	if client.IsNotFoundError(err) {
+		return fmt.Errorf("%w: the %q root volume deletion policy requires the Cinder backup API: %v", ErrInvalidArgument, cloudprovider.RootVolumeDeletionPolicyBackup, err)
+	}
 	if err != nil {
-		klog.Errorf("failed to delete port [Name=%q]", machineName)
-		return err
+		return fmt.Errorf("error listing backups of volume [ID=%q]: %w", volumeID, err)
 	}
-	return nil
+
+	if len(existing) == 0 {
+		// the volume has to be detached before it can be backed up
+		volume, err := ex.Storage.GetVolume(ctx, volumeID)
+		if err != nil {
+			if client.IsNotFoundError(err) {
+				return nil
+			}
+			return fmt.Errorf("error backing up volume [ID=%q]: %w", volumeID, err)
+		}
+		if volume.Status != client.VolumeStatusAvailable {
+			return ErrVolumeNotDeleted{VolumeID: volumeID, Err: fmt.Errorf("%w: volume has status %q", ErrDeletionPending, volume.Status)}
+		}
+
+		klog.V(2).Infof("backing up volume [Name=%q]", name)
+		backup, err := ex.Storage.CreateBackup(ctx, backups.CreateOpts{
+			VolumeID: volumeID,
+			Name:     name,
+		})
+		if client.IsNotFoundError(err) {
+			// Cinder reports a missing backup service as not found
+			return fmt.Errorf("%w: the %q root volume deletion policy requires the Cinder backup service: %v", ErrInvalidArgument, cloudprovider.RootVolumeDeletionPolicyBackup, err)
+		}
+		if err != nil {
+			return fmt.Errorf("error backing up volume [ID=%q]: %w", volumeID, err)
+		}
+		return ErrVolumeNotDeleted{VolumeID: volumeID, Err: fmt.Errorf("%w: backup [ID=%q] is being created", ErrDeletionPending, backup.ID)}
+	}
+
+	backup, err := ex.Storage.GetBackup(ctx, existing[0].ID)
+	if err != nil {
+		return fmt.Errorf("error fetching backup [ID=%q]: %w", existing[0].ID, err)
+	}
+
+	switch backup.Status {
+	case client.BackupStatusAvailable:
+		return nil
+	case client.BackupStatusCreating:
+		return ErrVolumeNotDeleted{VolumeID: volumeID, Err: fmt.Errorf("%w: backup [ID=%q] is being created", ErrDeletionPending, backup.ID)}
+	default:
+		return ErrVolumeNotDeleted{VolumeID: volumeID, Err: fmt.Errorf("backup [ID=%q] reached unexpected status %q: %s", backup.ID, backup.Status, backup.FailReason)}
+	}
+}
+
+// deleteVolume deletes the volume with the given name. It does not wait for the volume to be detached or to be gone,
+// but returns an ErrVolumeNotDeleted wrapping ErrDeletionPending instead, so that the deletion is resumed by the next
+// call. The deletion is confirmed once the volume can not be found anymore.
+func (ex *Executor) deleteVolume(ctx context.Context, name string) error {
+	volumeID, err := ex.Storage.VolumeIDFromName(ctx, name)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting [Name=%q]: %s", name, err)
+	}
+
+	volume, err := ex.Storage.GetVolume(ctx, volumeID)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting [Name=%q]: %s", name, err)
+	}
+
+	switch volume.Status {
+	case client.VolumeStatusAvailable, client.VolumeStatusError, client.VolumeStatusErrorDeleting:
+	default:
+		// Nova detaches the volume asynchronously after the server has been deleted
+		return ErrVolumeNotDeleted{VolumeID: volumeID, Err: fmt.Errorf("%w: volume has status %q", ErrDeletionPending, volume.Status)}
+	}
+
+	klog.V(2).Infof("deleting volume [Name=%q]", name)
+	err = ex.Storage.DeleteVolume(ctx, volumeID)
+	if err != nil && !client.IsNotFoundError(err) {
+		klog.Errorf("failed to delete volume [Name=%q]", name)
+		return ErrVolumeNotDeleted{VolumeID: volumeID, Err: err}
+	}
+	return ErrVolumeNotDeleted{VolumeID: volumeID, Err: fmt.Errorf("%w: volume is being deleted", ErrDeletionPending)}
+}
+
+// waitForVolumeDeletion deletes the volume with the given name and blocks until it is gone.
+func (ex *Executor) waitForVolumeDeletion(ctx context.Context, name string) error {
+	return wait.PollUntilContextTimeout(ctx, ex.pollInterval(), 600*time.Second, true, func(_ context.Context) (bool, error) {
+		err := ex.deleteVolume(ctx, name)
+		if errors.Is(err, ErrDeletionPending) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
+
+// getMachine fetches the server backing a machine. If a providerID is supplied it is used instead of the machineName to
+// locate the server.
+func (ex *Executor) getMachine(ctx context.Context, machineName, providerID string) (*servers.Server, error) {
+	if !isEmptyString(ptr.To(providerID)) {
+		return ex.getMachineByID(ctx, decodeProviderID(providerID))
+	}
+	return ex.getMachineByName(ctx, machineName)
 }
 
 // getMachineByProviderID fetches the data for a server based on a provider-encoded ID.
@@ -696,10 +2381,8 @@ func (ex *Executor) getMachineByID(ctx context.Context, serverID string) (*serve
 		return nil, fmt.Errorf("operation can not proceed: cluster/role tags are missing")
 	}
 
-	if _, nameOk := server.Metadata[searchClusterName]; nameOk {
-		if _, roleOk := server.Metadata[searchNodeRole]; roleOk {
-			return server, nil
-		}
+	if hasMandatoryTags(*server, searchClusterName, searchNodeRole) {
+		return server, nil
 	}
 
 	klog.Warningf("server [ID=%q] found, but cluster/role tags are missing/not matching", serverID)
@@ -709,9 +2392,8 @@ func (ex *Executor) getMachineByID(ctx context.Context, serverID string) (*serve
 // getMachineByName returns a server that matches the following criteria:
 // a) has the same name as machineName
 // b) has the cluster and role tags as set in the machineClass
-// The current approach is weak because the tags are currently stored as server metadata. Later Nova versions allow
-// to store tags in a respective field and do a server-side filtering. To avoid incompatibility with older versions
-// we will continue making the filtering clientside.
+// The tags are matched clientside against both the server tags and the server metadata, so that servers created before
+// server tags were supported by the cloud can still be found.
 func (ex *Executor) getMachineByName(ctx context.Context, machineName string) (*servers.Server, error) {
 	searchClusterName, searchNodeRole, ok := findMandatoryTags(ex.Config.Spec.Tags)
 	if !ok {
@@ -728,12 +2410,8 @@ func (ex *Executor) getMachineByName(ctx context.Context, machineName string) (*
 
 	var matchingServers []servers.Server
 	for _, server := range listedServers {
-		if server.Name == machineName {
-			if _, nameOk := server.Metadata[searchClusterName]; nameOk {
-				if _, roleOk := server.Metadata[searchNodeRole]; roleOk {
-					matchingServers = append(matchingServers, server)
-				}
-			}
+		if server.Name == machineName && hasMandatoryTags(server, searchClusterName, searchNodeRole) {
+			matchingServers = append(matchingServers, server)
 		}
 	}
 
@@ -746,6 +2424,147 @@ func (ex *Executor) getMachineByName(ctx context.Context, machineName string) (*
 	return &matchingServers[0], nil
 }
 
+// GetMachineStatus fetches the server backing the machine and reports its current status. If a providerID is supplied it
+// is used instead of the machineName to locate the server. Missing server tags of built servers are backfilled. Servers
+// that are deleted or marked for deletion are reported as ErrNotFound.
+func (ex *Executor) GetMachineStatus(ctx context.Context, machineName, providerID string) (*GetMachineStatusResult, error) {
+	server, err := ex.getMachine(ctx, machineName, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if server.Status == client.ServerStatusDeleted || server.Status == client.ServerStatusSoftDeleted {
+		return nil, fmt.Errorf("server [ID=%q] is in status %q: %w", server.ID, server.Status, ErrNotFound)
+	}
+
+	result := &GetMachineStatusResult{
+		ProviderID: ex.providerID(server),
+		Status:     server.Status,
+	}
+
+	switch server.Status {
+	case client.ServerStatusBuild:
+	case client.ServerStatusError:
+		result.Fault = server.Fault.Message
+	default:
+		// servers created before server tags were supported are tagged, so that they are found by the server-side filter
+		if err := ex.tagServer(ctx, server); err != nil {
+			return nil, err
+		}
+		result.Initialized, err = ex.isServerInitialized(ctx, machineName, server.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if server.Status != client.ServerStatusBuild {
+		result.ServerAddresses, err = ex.serverAddresses(ctx, machineName, server.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// InitializeMachine performs the setup of a server that can only happen once the server has been built, i.e. tagging the
+// server and the ports managed by MCM, reconciling the allowed address pairs of the server's ports and associating the
+// floating IP of asynchronously created servers. If a providerID is supplied it is used instead of the machineName to locate the server. InitializeMachine can be retried safely and
+// returns an error wrapping ErrNotInitialized if the server is not yet ready to be initialized.
+func (ex *Executor) InitializeMachine(ctx context.Context, machineName, providerID string) (*InitializeMachineResult, error) {
+	server, err := ex.getMachine(ctx, machineName, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch server.Status {
+	case client.ServerStatusBuild:
+		return nil, fmt.Errorf("server [ID=%q] has not finished building: %w", server.ID, ErrNotInitialized)
+	case client.ServerStatusError:
+		return nil, fmt.Errorf("server [ID=%q] reached unexpected status %q, fault: %+v", server.ID, server.Status, server.Fault)
+	}
+
+	if err := ex.tagServer(ctx, server); err != nil {
+		return nil, err
+	}
+
+	serverPorts, err := ex.listServerPorts(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the allowed address pairs are reconciled first, as tagging the ports changes their revision number
+	if err := ex.reconcileServerPortsAllowedAddressPairs(ctx, machineName, serverPorts); err != nil {
+		return nil, fmt.Errorf("failed to patch server [ID=%q] ports: %w", server.ID, err)
+	}
+
+	if err := ex.tagManagedPorts(ctx, machineName, serverPorts); err != nil {
+		return nil, fmt.Errorf("failed to tag server [ID=%q] ports: %w", server.ID, err)
+	}
+
+	// the floating IP of synchronously created servers is associated by CreateMachine
+	if ex.Config.Spec.FloatingIP != nil && ex.createsServersAsync() {
+		if _, err := ex.ensureFloatingIP(ctx, machineName, serverPorts); err != nil {
+			return nil, fmt.Errorf("failed to associate a floating IP with server [ID=%q]: %w", server.ID, err)
+		}
+	}
+
+	addresses, err := ex.serverAddresses(ctx, machineName, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InitializeMachineResult{
+		ProviderID:      ex.providerID(server),
+		ServerAddresses: addresses,
+	}, nil
+}
+
+// isServerInitialized checks whether the ports of a server have already been set up by InitializeMachine.
+func (ex *Executor) isServerInitialized(ctx context.Context, machineName, serverID string) (bool, error) {
+	serverPorts, err := ex.listServerPorts(ctx, serverID)
+	if err != nil {
+		return false, err
+	}
+
+	portTags, err := ex.managedTags()
+	if err != nil {
+		return false, err
+	}
+
+	podNetworkIDs, err := ex.resolveNetworkIDsForAllowedAddressPairs(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve network IDs for the pod network %v", err)
+	}
+
+	portNames := ex.managedPortNames(machineName)
+	unsecuredPortNames := ex.unsecuredPortNames(machineName)
+	for _, port := range serverPorts {
+		if portNames.Has(port.Name) && len(missingTags(port.Tags, portTags)) > 0 {
+			return false, nil
+		}
+		if unsecuredPortNames.Has(port.Name) {
+			continue
+		}
+		if _, _, changed := reconcileAllowedAddressPairs(port, ex.desiredAllowedAddressPairs(machineName, port, podNetworkIDs)); changed {
+			return false, nil
+		}
+	}
+
+	if ex.Config.Spec.FloatingIP != nil {
+		floatingIPs, err := ex.Network.ListFloatingIPs(ctx, floatingips.ListOpts{
+			PortID: ex.primaryPort(machineName, serverPorts).ID,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to list floating IPs: %v", err)
+		}
+		if len(floatingIPs) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // ListMachines lists returns a map from the server's encoded provider ID to the server name.
 func (ex *Executor) ListMachines(ctx context.Context) (map[string]string, error) {
 	allServers, err := ex.listServers(ctx)
@@ -755,14 +2574,17 @@ func (ex *Executor) ListMachines(ctx context.Context) (map[string]string, error)
 
 	result := map[string]string{}
 	for _, server := range allServers {
-		providerID := encodeProviderID(ex.Config.Spec.Region, server.ID)
+		providerID := ex.providerID(&server)
 		result[providerID] = server.Name
 	}
 
 	return result, nil
 }
 
-// ListServers lists all servers with the appropriate tags.
+// ListServers lists all servers with the appropriate tags. If the compute API supports server tags, the servers are
+// filtered server-side by their tags, servers created before only carry the tags in their metadata and are listed once
+// their tags have been backfilled by GetMachineStatus. Otherwise, all servers are listed and filtered clientside by
+// their metadata.
 func (ex *Executor) listServers(ctx context.Context) ([]servers.Server, error) {
 	searchClusterName, searchNodeRole, ok := findMandatoryTags(ex.Config.Spec.Tags)
 	if !ok {
@@ -770,23 +2592,101 @@ func (ex *Executor) listServers(ctx context.Context) ([]servers.Server, error) {
 		return nil, fmt.Errorf("list operation can not proceed: cluster/role tags are missing")
 	}
 
-	allServers, err := ex.Compute.ListServers(ctx, &servers.ListOpts{})
+	listOpts := &servers.ListOpts{}
+	if client.GetComputeCapabilities(ctx, ex.Compute).ServerTags {
+		listOpts.Tags = strings.Join([]string{searchClusterName, searchNodeRole}, ",")
+	}
+
+	allServers, err := ex.Compute.ListServers(ctx, listOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []servers.Server
 	for _, server := range allServers {
-		if _, nameOk := server.Metadata[searchClusterName]; nameOk {
-			if _, roleOk := server.Metadata[searchNodeRole]; roleOk {
-				result = append(result, server)
-			}
+		if hasMandatoryTags(server, searchClusterName, searchNodeRole) {
+			result = append(result, server)
 		}
 	}
 
 	return result, nil
 }
 
+// managedPort describes a port that is created and managed by MCM for a machine.
+type managedPort struct {
+	name                string
+	networkID           string
+	networkName         string
+	fixedIPs            []ports.IP
+	securityGroups      []string
+	disablePortSecurity bool
+	vnicType            string
+	bindingProfile      map[string]string
+	allowedAddressPairs []ports.AddressPair
+}
+
+// managedPorts returns the ports that are created and managed by MCM for the machine. These are the port in the network
+// specified by NetworkID if subnets are specified, or one port per entry of Networks if ManagedPorts is set.
+func (ex *Executor) managedPorts(machineName string) []managedPort {
+	if ex.isUserManagedNetwork() {
+		return []managedPort{{
+			name:           machineName,
+			networkID:      ex.Config.Spec.NetworkID,
+			fixedIPs:       ex.buildFixedIPs(),
+			securityGroups: ex.Config.Spec.SecurityGroups,
+		}}
+	}
+	if !ex.Config.Spec.ManagedPorts {
+		return nil
+	}
+
+	var managed []managedPort
+	for index, network := range ex.Config.Spec.Networks {
+		mp := managedPort{
+			name:                portName(machineName, index, network),
+			networkID:           network.Id,
+			networkName:         network.Name,
+			securityGroups:      network.SecurityGroups,
+			disablePortSecurity: network.DisablePortSecurity,
+			vnicType:            network.VNICType,
+			bindingProfile:      network.BindingProfile,
+			allowedAddressPairs: addressPairs(network.AllowedAddressPairs),
+		}
+		// physical functions passed through to the server can not be secured by security groups
+		if mp.securityGroups == nil && !network.DisablePortSecurity && network.VNICType != cloudprovider.VNICTypeDirectPhysical {
+			mp.securityGroups = ex.Config.Spec.SecurityGroups
+		}
+		for _, subnetID := range network.SubnetIDs {
+			mp.fixedIPs = append(mp.fixedIPs, ports.IP{SubnetID: subnetID})
+		}
+		for _, fixedIP := range network.FixedIPs {
+			mp.fixedIPs = append(mp.fixedIPs, ports.IP{SubnetID: fixedIP.SubnetID, IPAddress: fixedIP.IPAddress})
+		}
+		managed = append(managed, mp)
+	}
+	return managed
+}
+
+// managedPortNames returns the names of the ports that are created and managed by MCM for the machine.
+func (ex *Executor) managedPortNames(machineName string) sets.Set[string] {
+	names := sets.New[string]()
+	for _, mp := range ex.managedPorts(machineName) {
+		names.Insert(mp.name)
+	}
+	return names
+}
+
+// unsecuredPortNames returns the names of the ports managed by MCM for the machine that have port security disabled.
+func (ex *Executor) unsecuredPortNames(machineName string) sets.Set[string] {
+	names := sets.New[string]()
+	for _, mp := range ex.managedPorts(machineName) {
+		if mp.disablePortSecurity {
+			names.Insert(mp.name)
+		}
+	}
+	return names
+}
+
 // isUserManagedNetwork returns true if the port used by the machine will be created and managed by MCM.
 func (ex *Executor) isUserManagedNetwork() bool {
 	hasNetworkID := !isEmptyString(ptr.To(ex.Config.Spec.NetworkID))
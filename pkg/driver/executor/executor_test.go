@@ -5,19 +5,38 @@
 package executor
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/backups"
 	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/keypairs"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/portsbinding"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/portsecurity"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/provider"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/trunks"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
@@ -35,10 +54,13 @@ var _ = Describe("Executor", func() {
 		ctrl    *gomock.Controller
 		compute *mocks.MockCompute
 		network *mocks.MockNetwork
+		image   *mocks.MockImage
 		storage *mocks.MockStorage
 		tags    map[string]string
 		cfg     *openstack.MachineProviderConfig
 		ctx     context.Context
+
+		supportedMicroversions sets.Set[string]
 	)
 
 	BeforeEach(func() {
@@ -46,8 +68,14 @@ var _ = Describe("Executor", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		compute = mocks.NewMockCompute(ctrl)
 		network = mocks.NewMockNetwork(ctrl)
+		image = mocks.NewMockImage(ctrl)
 		storage = mocks.NewMockStorage(ctrl)
 
+		supportedMicroversions = sets.New[string]()
+		compute.EXPECT().SupportsMicroversion(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, version string) bool {
+			return supportedMicroversions.Has(version)
+		}).AnyTimes()
+
 		tags = map[string]string{
 			fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix): "1",
 			fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix):    "1",
@@ -66,6 +94,21 @@ var _ = Describe("Executor", func() {
 		ctrl.Finish()
 	})
 
+	expectServerAddresses := func(serverID string, serverPorts ...client.PortWithDNS) {
+		network.EXPECT().ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: serverID}).Return(serverPorts, nil)
+		for _, port := range serverPorts {
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: port.ID}).Return(nil, nil)
+		}
+	}
+
+	serverPort := func(id, networkID string, ips ...string) client.PortWithDNS {
+		port := client.PortWithDNS{Port: ports.Port{ID: id, NetworkID: networkID}}
+		for _, ip := range ips {
+			port.FixedIPs = append(port.FixedIPs, ports.IP{IPAddress: ip})
+		}
+		return port
+	}
+
 	Context("Create", func() {
 		var (
 			machineName = "name"
@@ -78,6 +121,15 @@ var _ = Describe("Executor", func() {
 			serverIPv4  = "10.250.0.5"
 			serverIPv6  = "2000:db0::1"
 		)
+		expectServerPorts := func() {
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				FixedIPs:            []ports.IP{{IPAddress: serverIPv4}},
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+		}
+
 		BeforeEach(func() {
 			cfg = &openstack.MachineProviderConfig{
 				Spec: openstack.MachineProviderConfigSpec{
@@ -114,21 +166,9 @@ var _ = Describe("Executor", func() {
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
 					ID:     serverID,
 					Status: client.ServerStatusActive,
-					Addresses: map[string]any{
-						"private": []any{
-							map[string]any{
-								"addr":    serverIPv4,
-								"version": 4,
-							},
-						},
-					},
 				}, nil))
-			network.EXPECT().ListPorts(ctx, &ports.ListOpts{
-				DeviceID: serverID,
-			}).Return([]ports.Port{{NetworkID: networkID, ID: portID}}, nil)
-			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
-				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidr}},
-			}).Return(nil)
+			expectServerPorts()
+			expectServerAddresses(serverID, serverPort(portID, networkID, serverIPv4))
 
 			server, err := ex.CreateMachine(ctx, machineName, nil)
 			Expect(err).ToNot(HaveOccurred())
@@ -136,6 +176,267 @@ var _ = Describe("Executor", func() {
 			Expect(server.InternalIPs[0]).To(Equal(serverIPv4))
 		})
 
+		It("should associate the floating IP and report it as external IP", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "ext"}
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
+				ID:     serverID,
+				Status: client.ServerStatusBuild,
+			}, nil)
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+				ID:     serverID,
+				Status: client.ServerStatusActive,
+			}, nil)
+			expectServerPorts()
+			fipTags := []string{cloudprovider.ServerTagClusterPrefix + "foo", cloudprovider.ServerTagRolePrefix + "foo"}
+			gomock.InOrder(
+				network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return(nil, nil),
+				network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{FloatingNetworkID: "ext", Description: machineName, Tags: strings.Join(fipTags, ",")}).Return(nil, nil),
+				network.EXPECT().CreateFloatingIP(ctx, floatingips.CreateOpts{
+					Description:       machineName,
+					FloatingNetworkID: "ext",
+					PortID:            portID,
+				}).Return(&floatingips.FloatingIP{ID: "fip", FloatingIP: "1.2.3.4"}, nil),
+				network.EXPECT().TagFloatingIP(ctx, "fip", fipTags).Return(nil),
+				network.EXPECT().ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]client.PortWithDNS{serverPort(portID, networkID, serverIPv4)}, nil),
+				network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return([]floatingips.FloatingIP{{ID: "fip", FloatingIP: "1.2.3.4"}}, nil),
+			)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.InternalIPs).To(ConsistOf(serverIPv4))
+			Expect(server.ExternalIPs).To(ConsistOf("1.2.3.4"))
+		})
+
+		It("should delete the server if the floating IP can not be associated", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "ext", Pool: []string{"1.2.3.4"}}
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			gomock.InOrder(
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil),
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{{ID: serverID, Name: machineName, Metadata: tags}}, nil),
+			)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			gomock.InOrder(
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
+					ID:     serverID,
+					Status: client.ServerStatusBuild,
+				}, nil),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:     serverID,
+					Status: client.ServerStatusActive,
+				}, nil),
+				compute.EXPECT().DeleteServer(ctx, serverID).Return(nil),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusDeleted}, nil),
+			)
+			expectServerPorts()
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return(nil, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{FloatingNetworkID: "ext"}).Return([]floatingips.FloatingIP{
+				{ID: "fip", FloatingIP: "1.2.3.4", PortID: "otherPort"},
+			}, nil).AnyTimes()
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(MatchError(ContainSubstring("failed to associate a floating IP")))
+		})
+
+		It("should not wait for the server to become active when AsyncServerCreation is enabled", func() {
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
+				ID:     serverID,
+				Status: client.ServerStatusBuild,
+			}, nil)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ProviderID).To(Equal(encodeProviderID(region, serverID)))
+			Expect(server.InternalIPs).To(BeEmpty())
+		})
+
+		It("should use the newest active image matching the image selector", func() {
+			cfg.Spec.ImageName = ""
+			cfg.Spec.ImageSelector = &openstack.ImageSelector{
+				Properties: map[string]string{"os_distro": "gardenlinux"},
+				Tags:       []string{"release"},
+				Visibility: "public",
+			}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Image:               image,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			now := time.Now()
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			image.EXPECT().ListImages(ctx, images.ListOpts{
+				Tags:       []string{"release"},
+				Visibility: images.ImageVisibilityPublic,
+				Status:     images.ImageStatusActive,
+			}).Return([]images.Image{
+				{ID: "old", Status: images.ImageStatusActive, CreatedAt: now.Add(-time.Hour), Properties: map[string]any{"os_distro": "gardenlinux"}},
+				{ID: "other", Status: images.ImageStatusActive, CreatedAt: now, Properties: map[string]any{"os_distro": "ubuntu"}},
+				{ID: "newB", Status: images.ImageStatusActive, CreatedAt: now, Properties: map[string]any{"os_distro": "gardenlinux"}},
+				{ID: "newA", Status: images.ImageStatusActive, CreatedAt: now, Properties: map[string]any{"os_distro": "gardenlinux"}},
+			}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.ImageRef).To(Equal("newA"))
+				Expect(createOpts.Metadata).To(HaveKeyWithValue(serverMetadataImageID, "newA"))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Spec.Tags).ToNot(HaveKey(serverMetadataImageID))
+		})
+
+		It("should set the server tags on creation if supported by the compute API", func() {
+			supportedMicroversions.Insert(client.MicroversionServerTags, client.MicroversionServerCreateTags)
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.Metadata).To(Equal(tags))
+				Expect(createOpts.Tags).To(ConsistOf(
+					fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix),
+					fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix),
+				))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should pin the server to the host and pass the trusted image certificates if supported by the compute API", func() {
+			supportedMicroversions.Insert(client.MicroversionHostPinning, client.MicroversionTrustedImageCertificates)
+			cfg.Spec.Host = "compute-1"
+			cfg.Spec.HypervisorHostname = "compute-1.example.com"
+			cfg.Spec.TrustedImageCertificates = []string{"certID"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				body, err := opts.ToServerCreateMap()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(body["server"]).To(And(
+					HaveKeyWithValue("host", "compute-1"),
+					HaveKeyWithValue("hypervisor_hostname", "compute-1.example.com"),
+					HaveKeyWithValue("trusted_image_certificates", []string{"certID"}),
+				))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should set the hostname and description rendered from the templates", func() {
+			supportedMicroversions.Insert(client.MicroversionServerDescription, client.MicroversionServerHostname)
+			cfg.Spec.Hostname = "{{ .MachineClassName }}-{{ .MachineName }}"
+			cfg.Spec.Description = "{{ .MachineName }} in {{ .Region }}"
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+				MachineClassName:    "class",
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				body, err := opts.ToServerCreateMap()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(body["server"]).To(And(
+					HaveKeyWithValue("name", machineName),
+					HaveKeyWithValue("hostname", "class-"+machineName),
+					HaveKeyWithValue("description", machineName+" in "+region),
+				))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			nodeName, err := ex.NodeName(machineName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeName).To(Equal("class-" + machineName))
+		})
+
+		It("should reject a FQDN if the compute API does not support it", func() {
+			supportedMicroversions.Insert(client.MicroversionServerHostname)
+			cfg.Spec.FQDN = "{{ .MachineName }}.example.com"
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil).Times(2)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(MatchError(ErrInvalidArgument))
+			Expect(err).To(MatchError(ContainSubstring(client.MicroversionServerFQDN)))
+		})
+
+		It("should reject host pinning if the compute API does not support it", func() {
+			cfg.Spec.Host = "compute-1"
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil).Times(2)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(MatchError(ErrInvalidArgument))
+			Expect(err).To(MatchError(ContainSubstring(client.MicroversionHostPinning)))
+		})
+
 		It("should succeed when spec contains subnet", func() {
 			subnetID := "subnetID"
 
@@ -150,7 +451,6 @@ var _ = Describe("Executor", func() {
 			network.EXPECT().GetSubnet(ctx, subnetID).Return(&subnets.Subnet{}, nil)
 			network.EXPECT().PortIDFromName(ctx, machineName).Return("", gophercloud.ErrResourceNotFound{})
 			network.EXPECT().CreatePort(ctx, gomock.Any()).Return(&ports.Port{ID: portID, Name: machineName}, nil)
-			network.EXPECT().TagPort(ctx, gomock.Any(), gomock.Any()).Return(nil)
 			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
 			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
 			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID}, nil)
@@ -158,10 +458,8 @@ var _ = Describe("Executor", func() {
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil),
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive}, nil),
 			)
-			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{NetworkID: networkID, ID: portID}}, nil)
-			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
-				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidr}},
-			}).Return(nil)
+			expectServerPorts()
+			expectServerAddresses(serverID)
 
 			server, err := ex.CreateMachine(ctx, machineName, nil)
 			Expect(err).ToNot(HaveOccurred())
@@ -184,7 +482,6 @@ var _ = Describe("Executor", func() {
 			network.EXPECT().GetSubnet(ctx, subnetID2).Return(&subnets.Subnet{}, nil)
 			network.EXPECT().PortIDFromName(ctx, machineName).Return("", gophercloud.ErrResourceNotFound{})
 			network.EXPECT().CreatePort(ctx, gomock.Any()).Return(&ports.Port{ID: portID, Name: machineName}, nil)
-			network.EXPECT().TagPort(ctx, gomock.Any(), gomock.Any()).Return(nil)
 			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
 			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
 			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID}, nil)
@@ -192,14 +489,53 @@ var _ = Describe("Executor", func() {
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil),
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive}, nil),
 			)
-			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{NetworkID: networkID, ID: portID}}, nil)
+			expectServerPorts()
+			expectServerAddresses(serverID)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ProviderID).To(Equal(encodeProviderID(region, serverID)))
+		})
+
+		It("should allow the IPv6 pod network on a port created in an IPv6-only subnet", func() {
+			var (
+				subnetID    = "subnetIDv6"
+				podCidrIPv6 = "fd00:10:96::/48"
+			)
+			cfg.Spec.SubnetIDs = []string{subnetID}
+			cfg.Spec.PodNetworkCidr = ""
+			cfg.Spec.PodNetworkCIDRs = []string{podCidrIPv6}
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			network.EXPECT().GetSubnet(ctx, subnetID).Return(&subnets.Subnet{}, nil)
+			network.EXPECT().PortIDFromName(ctx, machineName).Return("", gophercloud.ErrResourceNotFound{})
+			network.EXPECT().CreatePort(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, opts ports.CreateOptsBuilder) (*ports.Port, error) {
+				Expect(opts.(*ports.CreateOpts).FixedIPs).To(Equal([]ports.IP{{SubnetID: subnetID}}))
+				return &ports.Port{ID: portID, Name: machineName}, nil
+			})
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID}, nil)
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:        portID,
+				NetworkID: networkID,
+				FixedIPs:  []ports.IP{{SubnetID: subnetID, IPAddress: serverIPv6}},
+			}}, nil)
 			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
-				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidr}},
+				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidrIPv6}},
 			}).Return(nil)
+			network.EXPECT().TagPort(ctx, portID, []string{allowedAddressPairTagPrefix + podCidrIPv6}).Return(nil)
+			expectServerAddresses(serverID, serverPort(portID, networkID, serverIPv6))
 
 			server, err := ex.CreateMachine(ctx, machineName, nil)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(server.ProviderID).To(Equal(encodeProviderID(region, serverID)))
+			Expect(server.InternalIPs).To(Equal([]string{serverIPv6}))
 		})
 
 		It("should succeed when spec contains rootDisksize", func() {
@@ -233,132 +569,1096 @@ var _ = Describe("Executor", func() {
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil),
 				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive}, nil),
 			)
-			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{NetworkID: networkID, ID: portID}}, nil)
-			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
-				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidr}},
-			}).Return(nil)
+			expectServerPorts()
+			expectServerAddresses(serverID)
 
 			server, err := ex.CreateMachine(ctx, machineName, nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(server.ProviderID).To(Equal(encodeProviderID(region, serverID)))
 		})
 
-		It("should raise a ErrResourceNotFound error when called with a missing flavor", func() {
+		It("should leave the ports of the networks to Nova without managed ports", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{
+				{Name: "netA", PodNetwork: true},
+				{Id: "netB"},
+			}
 			ex := &Executor{
-				Compute: compute,
-				Network: network,
-				Config:  cfg,
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
 			}
 
 			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			network.EXPECT().NetworkIDFromName(ctx, "netA").Return("netA-id", nil)
 			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
-			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return(flavorName, gophercloud.ErrResourceNotFound{Name: flavorName, ResourceType: "flavor"})
-			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.Networks).To(Equal([]servers.Network{
+					{UUID: "netA-id"},
+					{UUID: "netB"},
+				}))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
 
 			_, err := ex.CreateMachine(ctx, machineName, nil)
-			Expect(err).To(HaveOccurred())
-			Expect(errors.Is(err, ErrFlavorNotFound{Flavor: "flavor"})).To(BeTrue())
-			Expect(errors.As(err, &ErrFlavorNotFound{})).To(BeTrue())
+			Expect(err).ToNot(HaveOccurred())
 		})
 
-		It("should delete the server on failure", func() {
+		It("should create a managed port per network", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{
+				{Name: "netA", SubnetIDs: []string{"subnetID"}, PodNetwork: true},
+				{Id: "netB", FixedIPs: []openstack.FixedIP{{IPAddress: "10.1.0.5"}}, PortNameSuffix: "storage", DisablePortSecurity: true},
+			}
 			ex := &Executor{
-				Compute: compute,
-				Network: network,
-				Config:  cfg,
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
 			}
 
-			server := &servers.Server{
-				Metadata: tags,
-				ID:       serverID,
-				Name:     machineName,
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			network.EXPECT().GetSubnet(ctx, "subnetID").Return(&subnets.Subnet{}, nil)
+			network.EXPECT().NetworkIDFromName(ctx, "netA").Return("netA-id", nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-0").Return("", gophercloud.ErrResourceNotFound{})
+			network.EXPECT().CreatePort(ctx, &ports.CreateOpts{
+				Name:           machineName + "-0",
+				NetworkID:      "netA-id",
+				FixedIPs:       []ports.IP{{SubnetID: "subnetID"}},
+				SecurityGroups: ptr.To([]string(nil)),
+			}).Return(&ports.Port{ID: "port0"}, nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-storage").Return("", gophercloud.ErrResourceNotFound{})
+			network.EXPECT().CreatePort(ctx, portsecurity.PortCreateOptsExt{
+				CreateOptsBuilder: &ports.CreateOpts{
+					Name:           machineName + "-storage",
+					NetworkID:      "netB",
+					FixedIPs:       []ports.IP{{IPAddress: "10.1.0.5"}},
+					SecurityGroups: ptr.To([]string(nil)),
+				},
+				PortSecurityEnabled: ptr.To(false),
+			}).Return(&ports.Port{ID: "port1"}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.Networks).To(Equal([]servers.Network{
+					{UUID: "netA-id", Port: "port0"},
+					{UUID: "netB", Port: "port1"},
+				}))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should create SR-IOV ports with the requested binding options", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{
+				{Id: "sriov", VNICType: cloudprovider.VNICTypeDirect, BindingProfile: map[string]string{"trusted": "true"}},
+			}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
 			}
 
 			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			network.EXPECT().GetNetworkProviderAttributes(ctx, "sriov").Return(&provider.NetworkProviderExt{NetworkType: "vlan", PhysicalNetwork: "physnet1"}, nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-0").Return("", gophercloud.ErrResourceNotFound{})
+			network.EXPECT().CreatePort(ctx, portsbinding.CreateOptsExt{
+				CreateOptsBuilder: &ports.CreateOpts{
+					Name:           machineName + "-0",
+					NetworkID:      "sriov",
+					SecurityGroups: ptr.To([]string(nil)),
+				},
+				VNICType: cloudprovider.VNICTypeDirect,
+				Profile:  map[string]any{"trusted": "true"},
+			}).Return(&ports.Port{ID: "port0"}, nil)
 			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
 			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
-			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
-				ID: serverID,
-			}, nil)
-
-			gomock.InOrder(
-				// we return an error to avoid waiting for the wait.Poll timeout
-				compute.EXPECT().GetServer(ctx, serverID).Return(nil, fmt.Errorf("error fetching server")),
-				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{*server}, nil),
-				compute.EXPECT().DeleteServer(ctx, serverID).Return(nil),
-				compute.EXPECT().GetServer(ctx, serverID).Do(func(_ context.Context, _ string) { server.Status = client.ServerStatusDeleted }).Return(server, nil),
-			)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil)
 
 			_, err := ex.CreateMachine(ctx, machineName, nil)
-			Expect(err).To(HaveOccurred())
+			Expect(err).ToNot(HaveOccurred())
 		})
 
-		It("should accept multiple internal IPs", func() {
+		It("should reject SR-IOV ports on networks without physical network", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{
+				{Id: "overlay", VNICType: cloudprovider.VNICTypeDirect},
+			}
 			ex := &Executor{
 				Compute: compute,
 				Network: network,
 				Config:  cfg,
 			}
 
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil).Times(2)
+			network.EXPECT().GetNetworkProviderAttributes(ctx, "overlay").Return(&provider.NetworkProviderExt{NetworkType: "vxlan"}, nil)
+			network.EXPECT().ListPorts(ctx, ports.ListOpts{Name: machineName + "-0"}).Return([]ports.Port{}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(MatchError(ErrInvalidArgument))
+		})
+
+		It("should create a trunk with its subports before the server", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{Id: "netA"}}
+			cfg.Spec.Trunk = &openstack.Trunk{SubPorts: []openstack.SubPort{
+				{NetworkID: "vlanNetA", SegmentationID: 100},
+				{NetworkName: "vlanNetB", SegmentationID: 200},
+			}}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
 			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-0").Return("port0", nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-vlan-100").Return("subport100", nil)
+			network.EXPECT().NetworkIDFromName(ctx, "vlanNetB").Return("vlanNetB-id", nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-vlan-200").Return("", gophercloud.ErrResourceNotFound{})
+			network.EXPECT().CreatePort(ctx, &ports.CreateOpts{
+				Name:           machineName + "-vlan-200",
+				NetworkID:      "vlanNetB-id",
+				SecurityGroups: ptr.To([]string(nil)),
+			}).Return(&ports.Port{ID: "subport200"}, nil)
+			network.EXPECT().ListTrunks(ctx, trunks.ListOpts{PortID: "port0"}).Return([]trunks.Trunk{}, nil)
+			network.EXPECT().CreateTrunk(ctx, trunks.CreateOpts{
+				Name:   machineName,
+				PortID: "port0",
+				Subports: []trunks.Subport{
+					{PortID: "subport100", SegmentationType: "vlan", SegmentationID: 100},
+					{PortID: "subport200", SegmentationType: "vlan", SegmentationID: 200},
+				},
+			}).Return(&trunks.Trunk{ID: "trunk"}, nil)
 			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
 			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
-			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
-				ID: serverID,
-			}, nil)
-			gomock.InOrder(
-				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
-					ID:     serverID,
-					Status: client.ServerStatusBuild,
-				}, nil),
-				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
-					ID:     serverID,
-					Status: client.ServerStatusActive,
-					Addresses: map[string]any{
-						"private": []any{
-							map[string]any{
-								"addr":    serverIPv4,
-								"version": 4,
-							},
-							map[string]any{
-								"addr":    serverIPv6,
-								"version": 6,
-							},
-						},
-					},
-				}, nil))
-			network.EXPECT().ListPorts(ctx, &ports.ListOpts{
-				DeviceID: serverID,
-			}).Return([]ports.Port{{NetworkID: networkID, ID: portID}}, nil)
-			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
-				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidr}},
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should add missing subports to an existing trunk", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{Id: "netA"}}
+			cfg.Spec.Trunk = &openstack.Trunk{SubPorts: []openstack.SubPort{
+				{NetworkID: "vlanNetA", SegmentationID: 100},
+				{NetworkID: "vlanNetB", SegmentationID: 200},
+			}}
+			ex := &Executor{
+				Network: network,
+				Config:  cfg,
+			}
+
+			network.EXPECT().PortIDFromName(ctx, machineName+"-vlan-100").Return("subport100", nil)
+			network.EXPECT().PortIDFromName(ctx, machineName+"-vlan-200").Return("subport200", nil)
+			network.EXPECT().ListTrunks(ctx, trunks.ListOpts{PortID: "port0"}).Return([]trunks.Trunk{{
+				ID:       "trunk",
+				Subports: []trunks.Subport{{PortID: "subport100", SegmentationType: "vlan", SegmentationID: 100}},
+			}}, nil)
+			network.EXPECT().AddSubports(ctx, "trunk", trunks.AddSubportsOpts{
+				Subports: []trunks.Subport{{PortID: "subport200", SegmentationType: "vlan", SegmentationID: 200}},
 			}).Return(nil)
 
-			server, err := ex.CreateMachine(ctx, machineName, nil)
+			err := ex.ensureTrunk(ctx, machineName, ex.managedPorts(machineName)[0], "port0")
 			Expect(err).ToNot(HaveOccurred())
-			Expect(server.InternalIPs).To(HaveLen(2))
-			Expect(server.InternalIPs).To(ConsistOf(serverIPv4, serverIPv6))
 		})
-	})
 
-	Context("List", func() {
-		It("should filter the instances based on tags", func() {
-			compute.EXPECT().ListServers(ctx, gomock.Any()).Return(
-				[]servers.Server{
+		It("should create and attach the data volumes", func() {
+			var (
+				volumeType = "fast"
+				volumeID   = "volumeID"
+			)
+			cfg.Spec.DataVolumes = []openstack.DataVolume{{Name: "data", Size: 10, Type: &volumeType}}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Storage:             storage,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			storage.EXPECT().VolumeIDFromName(ctx, machineName+"-data").Return("", gophercloud.ErrResourceNotFound{})
+			storage.EXPECT().CreateVolume(ctx, volumes.CreateOpts{
+				Name:       machineName + "-data",
+				VolumeType: volumeType,
+				Size:       10,
+				Metadata:   tags,
+			}, nil).Return(&volumes.Volume{ID: volumeID}, nil)
+			storage.EXPECT().GetVolume(ctx, volumeID).Return(&volumes.Volume{ID: volumeID, Status: client.VolumeStatusAvailable}, nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.BlockDevice).To(Equal([]servers.BlockDevice{
+					{UUID: "imageID", BootIndex: 0, DeleteOnTermination: true, SourceType: "image", DestinationType: "local"},
+					{UUID: volumeID, BootIndex: -1, SourceType: "volume", DestinationType: "volume"},
+				}))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should create the volumes in the volume availability zone with the Cinder scheduler hints", func() {
+			var (
+				volumeType = "fast"
+				volumeID   = "volumeID"
+			)
+			cfg.Spec.AvailabilityZone = "compute-zone"
+			cfg.Spec.RootDiskType = &volumeType
+			cfg.Spec.RootDiskSize = 50
+			cfg.Spec.VolumeAvailabilityZone = "volume-zone"
+			cfg.Spec.VolumeSchedulerHints = &openstack.VolumeSchedulerHints{
+				DifferentHost: []string{"0b4e8e7c-3f1a-4d2b-8c9d-1a2b3c4d5e6f"},
+			}
+			cfg.Spec.VolumeMetadata = map[string]string{"backup": "daily"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Storage:             storage,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			storage.EXPECT().VolumeIDFromName(ctx, machineName).Return("", gophercloud.ErrResourceNotFound{})
+			metadata := map[string]string{"backup": "daily"}
+			for k, v := range tags {
+				metadata[k] = v
+			}
+			storage.EXPECT().CreateVolume(ctx, volumes.CreateOpts{
+				Name:             machineName,
+				VolumeType:       volumeType,
+				Size:             50,
+				ImageID:          "imageID",
+				AvailabilityZone: "volume-zone",
+				Metadata:         metadata,
+			}, volumes.SchedulerHintOpts{
+				DifferentHost: []string{"0b4e8e7c-3f1a-4d2b-8c9d-1a2b3c4d5e6f"},
+			}).Return(&volumes.Volume{ID: volumeID}, nil)
+			storage.EXPECT().GetVolume(ctx, volumeID).Return(&volumes.Volume{ID: volumeID, Status: client.VolumeStatusAvailable}, nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.AvailabilityZone).To(Equal("compute-zone"))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should boot from a volume created by Nova from the root volume snapshot", func() {
+			cfg.Spec.ImageName = ""
+			cfg.Spec.RootDiskSize = 50
+			cfg.Spec.RootVolumeSource = &openstack.RootVolumeSource{SnapshotName: "golden"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Storage:             storage,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			storage.EXPECT().SnapshotIDFromName(ctx, "golden").Return("snapshotID", nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.ImageRef).To(BeEmpty())
+				Expect(createOpts.BlockDevice).To(Equal([]servers.BlockDevice{
+					{UUID: "snapshotID", VolumeSize: 50, BootIndex: 0, DeleteOnTermination: true, SourceType: "snapshot", DestinationType: "volume"},
+				}))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should boot from a clone of the golden volume", func() {
+			cfg.Spec.ImageName = ""
+			cfg.Spec.RootVolumeSource = &openstack.RootVolumeSource{VolumeID: "goldenID"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Storage:             storage,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			storage.EXPECT().VolumeIDFromName(ctx, machineName).Return("", gophercloud.ErrResourceNotFound{})
+			storage.EXPECT().CreateVolume(ctx, volumes.CreateOpts{
+				Name:        machineName,
+				SourceVolID: "goldenID",
+				Metadata:    tags,
+			}, nil).Return(&volumes.Volume{ID: "volumeID"}, nil)
+			storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.BlockDevice).To(Equal([]servers.BlockDevice{
+					{UUID: "volumeID", BootIndex: 0, SourceType: "volume", DestinationType: "volume"},
+				}))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should add the ephemeral and swap disks fitting into the flavor", func() {
+			cfg.Spec.BlockDevices = []openstack.BlockDevice{
+				{Type: "ephemeral", GuestFormat: "ext4", DeviceName: "/dev/vdb"},
+				{Type: "swap", Size: 512},
+			}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().GetFlavor(ctx, "flavorID").Return(&flavors.Flavor{ID: "flavorID", Ephemeral: 20, Swap: 1024}, nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				serverCreateOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(blockDeviceNamesOpts)
+				Expect(serverCreateOpts.CreateOptsBuilder.(*servers.CreateOpts).BlockDevice).To(Equal([]servers.BlockDevice{
+					{UUID: "imageID", BootIndex: 0, DeleteOnTermination: true, SourceType: "image", DestinationType: "local"},
+					{BootIndex: -1, DeleteOnTermination: true, SourceType: "blank", DestinationType: "local", GuestFormat: "ext4"},
+					{BootIndex: -1, DeleteOnTermination: true, SourceType: "blank", DestinationType: "local", GuestFormat: "swap", VolumeSize: 512},
+				}))
+
+				body, err := opts.ToServerCreateMap()
+				Expect(err).ToNot(HaveOccurred())
+				blockDevices := body["server"].(map[string]any)["block_device_mapping_v2"].([]any)
+				Expect(blockDevices[1]).To(HaveKeyWithValue("device_name", "/dev/vdb"))
+				Expect(blockDevices[2]).ToNot(HaveKey("device_name"))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should skip flavors without room for the ephemeral disks", func() {
+			cfg.Spec.FlavorNames = []string{"fallback"}
+			cfg.Spec.BlockDevices = []openstack.BlockDevice{{Type: "ephemeral", Size: 10}}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().GetFlavor(ctx, "flavorID").Return(&flavors.Flavor{ID: "flavorID", Name: flavorName, Ephemeral: 5}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, "fallback").Return("fallbackID", nil)
+			compute.EXPECT().GetFlavor(ctx, "fallbackID").Return(&flavors.Flavor{ID: "fallbackID", Name: "fallback", Ephemeral: 10}, nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.FlavorRef).To(Equal("fallbackID"))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("oversized user data", func() {
+			var objectStorage *mocks.MockObjectStorage
+
+			BeforeEach(func() {
+				objectStorage = mocks.NewMockObjectStorage(ctrl)
+			})
+
+			It("should compress the user data", func() {
+				userData := []byte(strings.Repeat("#cloud-config\n", 10000))
+				ex := &Executor{
+					Compute:             compute,
+					Network:             network,
+					Config:              cfg,
+					AsyncServerCreation: true,
+				}
+
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+				compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+				compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					reader, err := gzip.NewReader(bytes.NewReader(createOpts.UserData))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(io.ReadAll(reader)).To(Equal(userData))
+					return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+				})
+
+				_, err := ex.CreateMachine(ctx, machineName, userData)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should stage the user data in Swift if it is too large to be compressed", func() {
+				userData := incompressibleUserData()
+				cfg.Spec.UserDataOptions = &openstack.UserDataOptions{
+					SwiftContainer:  "userdata",
+					TempURLValidity: &metav1.Duration{Duration: time.Hour},
+				}
+				ex := &Executor{
+					Compute:             compute,
+					Network:             network,
+					ObjectStorage:       objectStorage,
+					Config:              cfg,
+					AsyncServerCreation: true,
+				}
+
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+				objectStorage.EXPECT().CreateObject(ctx, "userdata", machineName, userData).Return(nil)
+				objectStorage.EXPECT().CreateTempURL(ctx, "userdata", machineName, time.Hour).Return("https://swift/userdata/name?temp_url_sig=sig", nil)
+				compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+				compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					Expect(string(createOpts.UserData)).To(Equal("#include\nhttps://swift/userdata/name?temp_url_sig=sig\n"))
+					return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+				})
+
+				_, err := ex.CreateMachine(ctx, machineName, userData)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should keep the staged user data when falling back to the next availability zone", func() {
+				userData := incompressibleUserData()
+				cfg.Spec.AvailabilityZone = "zone-a"
+				cfg.Spec.FallbackAvailabilityZones = []string{"zone-b"}
+				cfg.Spec.UserDataOptions = &openstack.UserDataOptions{SwiftContainer: "userdata"}
+				ex := &Executor{
+					Compute:       compute,
+					Network:       network,
+					ObjectStorage: objectStorage,
+					Config:        cfg,
+				}
+
+				failedServer := servers.Server{ID: "failed", Name: machineName, Metadata: tags}
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+				objectStorage.EXPECT().CreateObject(ctx, "userdata", machineName, userData).Return(nil)
+				objectStorage.EXPECT().CreateTempURL(ctx, "userdata", machineName, 24*time.Hour).Return("https://swift/userdata/name?temp_url_sig=sig", nil)
+				compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil).Times(2)
+				compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil).Times(2)
+				gomock.InOrder(
+					compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: "failed"}, nil),
+					compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{
+						ID:     "failed",
+						Status: client.ServerStatusError,
+						Fault:  servers.Fault{Message: "No valid host was found."},
+					}, nil),
+					compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{failedServer}, nil),
+					compute.EXPECT().DeleteServer(ctx, "failed").Return(nil),
+					compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{ID: "failed", Status: client.ServerStatusDeleted}, nil),
+					compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+						createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+						Expect(string(createOpts.UserData)).To(Equal("#include\nhttps://swift/userdata/name?temp_url_sig=sig\n"))
+						return &servers.Server{ID: serverID}, nil
+					}),
+					compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive}, nil),
+				)
+				expectServerPorts()
+				expectServerAddresses(serverID)
+
+				_, err := ex.CreateMachine(ctx, machineName, userData)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should reject user data which neither fits compressed nor can be staged", func() {
+				ex := &Executor{
+					Compute:             compute,
+					Network:             network,
+					Config:              cfg,
+					AsyncServerCreation: true,
+				}
+
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil).Times(2)
+				network.EXPECT().ListPorts(ctx, gomock.Any()).Return(nil, nil).AnyTimes()
+
+				_, err := ex.CreateMachine(ctx, machineName, incompressibleUserData())
+				Expect(errors.Is(err, ErrInvalidArgument)).To(BeTrue())
+			})
+		})
+
+		It("should pass the scheduler hints to the server creation", func() {
+			cfg.Spec.ServerGroupID = ptr.To("6f1c5b3a-8d2e-4c7f-9a1b-2e3d4c5b6a7f")
+			cfg.Spec.SchedulerHints = &openstack.SchedulerHints{
+				DifferentHost:   []string{"0b4e8e7c-3f1a-4d2b-8c9d-1a2b3c4d5e6f"},
+				Query:           `[">=", "$free_ram_mb", 1024]`,
+				TargetCell:      "cell1",
+				BuildNearHostIP: "192.168.1.0/24",
+			}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), servers.SchedulerHintOpts{
+				Group:           "6f1c5b3a-8d2e-4c7f-9a1b-2e3d4c5b6a7f",
+				DifferentHost:   []string{"0b4e8e7c-3f1a-4d2b-8c9d-1a2b3c4d5e6f"},
+				Query:           []any{">=", "$free_ram_mb", float64(1024)},
+				TargetCell:      "cell1",
+				BuildNearHostIP: "192.168.1.0/24",
+			}).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should create the managed server group and schedule the server into it", func() {
+			cfg.Spec.ServerGroup = &openstack.ServerGroup{Name: "workers", Policy: "soft-anti-affinity"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			gomock.InOrder(
+				compute.EXPECT().ListServerGroups(ctx).Return([]servergroups.ServerGroup{{ID: "other", Name: "other"}}, nil),
+				compute.EXPECT().CreateServerGroup(ctx, servergroups.CreateOpts{
+					Name:     "workers",
+					Policies: []string{"soft-anti-affinity"},
+				}).Return(&servergroups.ServerGroup{ID: "groupID", Name: "workers"}, nil),
+				compute.EXPECT().ListServerGroups(ctx).Return([]servergroups.ServerGroup{{ID: "groupID", Name: "workers"}}, nil),
+			)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), servers.SchedulerHintOpts{Group: "groupID"}).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should reuse the existing managed server group", func() {
+			cfg.Spec.ServerGroup = &openstack.ServerGroup{Name: "workers", Policy: "anti-affinity"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().ListServerGroups(ctx).Return([]servergroups.ServerGroup{
+				{ID: "groupB", Name: "workers", Policy: ptr.To("anti-affinity")},
+				{ID: "groupA", Name: "workers", Policies: []string{"anti-affinity"}},
+			}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), servers.SchedulerHintOpts{Group: "groupA"}).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should reject a managed server group with a different policy", func() {
+			cfg.Spec.ServerGroup = &openstack.ServerGroup{Name: "workers", Policy: "anti-affinity"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil).Times(2)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().ListServerGroups(ctx).Return([]servergroups.ServerGroup{
+				{ID: "groupID", Name: "workers", Policy: ptr.To("affinity"), Members: []string{"other"}},
+			}, nil).Times(2)
+			network.EXPECT().ListPorts(ctx, gomock.Any()).Return(nil, nil).AnyTimes()
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(errors.Is(err, ErrInvalidArgument)).To(BeTrue())
+		})
+
+		It("should fall back to the next flavor if a flavor is missing", func() {
+			cfg.Spec.FlavorNames = []string{"fallback"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("", gophercloud.ErrResourceNotFound{})
+			compute.EXPECT().FlavorIDFromName(ctx, "fallback").Return("fallbackID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.FlavorRef).To(Equal("fallbackID"))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should replace the server with the next flavor if no valid host is found", func() {
+			cfg.Spec.FlavorNames = []string{"fallback"}
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().FlavorIDFromName(ctx, "fallback").Return("fallbackID", nil)
+			gomock.InOrder(
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: "failed"}, nil),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{
+					ID:     "failed",
+					Status: client.ServerStatusError,
+					Fault:  servers.Fault{Message: "No valid host was found. There are not enough hosts available."},
+				}, nil),
+				compute.EXPECT().DeleteServer(ctx, "failed").Return(nil),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{ID: "failed", Status: client.ServerStatusDeleted}, nil),
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					Expect(createOpts.FlavorRef).To(Equal("fallbackID"))
+					return &servers.Server{ID: serverID}, nil
+				}),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:     serverID,
+					Status: client.ServerStatusActive,
+				}, nil),
+			)
+			expectServerPorts()
+			expectServerAddresses(serverID, serverPort(portID, networkID, serverIPv4))
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ProviderID).To(Equal(encodeProviderID(region, serverID)))
+			Expect(server.InternalIPs).To(ConsistOf(serverIPv4))
+		})
+
+		It("should retry in the fallback availability zone if no valid host is found", func() {
+			cfg.Spec.AvailabilityZone = "zone-a"
+			cfg.Spec.FallbackAvailabilityZones = []string{"zone-b"}
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			failedServer := servers.Server{ID: "failed", Name: machineName, Metadata: tags}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil).Times(2)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil).Times(2)
+			gomock.InOrder(
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					Expect(createOpts.AvailabilityZone).To(Equal("zone-a"))
+					return &servers.Server{ID: "failed"}, nil
+				}),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{
+					ID:     "failed",
+					Status: client.ServerStatusError,
+					Fault:  servers.Fault{Message: "No valid host was found."},
+				}, nil),
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{failedServer}, nil),
+				compute.EXPECT().DeleteServer(ctx, "failed").Return(nil),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{ID: "failed", Status: client.ServerStatusDeleted}, nil),
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					Expect(createOpts.AvailabilityZone).To(Equal("zone-b"))
+					Expect(createOpts.Metadata).To(HaveKeyWithValue(serverMetadataAvailabilityZone, "zone-b"))
+					return &servers.Server{ID: serverID}, nil
+				}),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:               serverID,
+					Status:           client.ServerStatusActive,
+					AvailabilityZone: "zone-b",
+				}, nil),
+			)
+			expectServerPorts()
+			expectServerAddresses(serverID)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ProviderID).To(Equal(encodeZonalProviderID(region, "zone-b", serverID)))
+			Expect(server.AvailabilityZone).To(Equal("zone-b"))
+		})
+
+		It("should wait for the server build to retry in the fallback availability zone if the server creation is asynchronous", func() {
+			cfg.Spec.AvailabilityZone = "zone-a"
+			cfg.Spec.FallbackAvailabilityZones = []string{"zone-b"}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			failedServer := servers.Server{ID: "failed", Name: machineName, Metadata: tags}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil).Times(2)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil).Times(2)
+			gomock.InOrder(
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					Expect(createOpts.AvailabilityZone).To(Equal("zone-a"))
+					return &servers.Server{ID: "failed"}, nil
+				}),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{
+					ID:     "failed",
+					Status: client.ServerStatusError,
+					Fault:  servers.Fault{Message: "No valid host was found."},
+				}, nil),
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{failedServer}, nil),
+				compute.EXPECT().DeleteServer(ctx, "failed").Return(nil),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{ID: "failed", Status: client.ServerStatusDeleted}, nil),
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+					createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+					Expect(createOpts.AvailabilityZone).To(Equal("zone-b"))
+					Expect(createOpts.Metadata).To(HaveKeyWithValue(serverMetadataAvailabilityZone, "zone-b"))
+					return &servers.Server{ID: serverID}, nil
+				}),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:               serverID,
+					Status:           client.ServerStatusActive,
+					AvailabilityZone: "zone-b",
+				}, nil),
+			)
+			expectServerPorts()
+			expectServerAddresses(serverID)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ProviderID).To(Equal(encodeZonalProviderID(region, "zone-b", serverID)))
+			Expect(server.AvailabilityZone).To(Equal("zone-b"))
+		})
+
+		It("should remove the volumes of the failed attempt regardless of the deletion policies", func() {
+			cfg.Spec.AvailabilityZone = "zone-a"
+			cfg.Spec.FallbackAvailabilityZones = []string{"zone-b"}
+			cfg.Spec.DataVolumes = []openstack.DataVolume{{Name: "data", Size: 10, DeleteOnTermination: ptr.To(false)}}
+			ex := &Executor{
+				Compute:      compute,
+				Network:      network,
+				Storage:      storage,
+				Config:       cfg,
+				PollInterval: time.Millisecond,
+			}
+
+			failedServer := servers.Server{ID: "failed", Name: machineName, Metadata: tags}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil).Times(2)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil).Times(2)
+			gomock.InOrder(
+				storage.EXPECT().VolumeIDFromName(ctx, machineName+"-data").Return("", gophercloud.ErrResourceNotFound{}),
+				storage.EXPECT().CreateVolume(ctx, gomock.Any(), gomock.Any()).Return(&volumes.Volume{ID: "volumeA"}, nil),
+				storage.EXPECT().GetVolume(ctx, "volumeA").Return(&volumes.Volume{ID: "volumeA", Status: client.VolumeStatusAvailable}, nil),
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: "failed"}, nil),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{
+					ID:     "failed",
+					Status: client.ServerStatusError,
+					Fault:  servers.Fault{Message: "No valid host was found."},
+				}, nil),
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{failedServer}, nil),
+				compute.EXPECT().DeleteServer(ctx, "failed").Return(nil),
+				compute.EXPECT().GetServer(ctx, "failed").Return(&servers.Server{ID: "failed", Status: client.ServerStatusDeleted}, nil),
+				storage.EXPECT().VolumeIDFromName(ctx, machineName+"-data").Return("volumeA", nil),
+				storage.EXPECT().GetVolume(ctx, "volumeA").Return(&volumes.Volume{ID: "volumeA", Status: client.VolumeStatusDetaching}, nil),
+				storage.EXPECT().VolumeIDFromName(ctx, machineName+"-data").Return("volumeA", nil),
+				storage.EXPECT().GetVolume(ctx, "volumeA").Return(&volumes.Volume{ID: "volumeA", Status: client.VolumeStatusAvailable}, nil),
+				storage.EXPECT().DeleteVolume(ctx, "volumeA").Return(nil),
+				storage.EXPECT().VolumeIDFromName(ctx, machineName+"-data").Return("", gophercloud.ErrResourceNotFound{}),
+				storage.EXPECT().VolumeIDFromName(ctx, machineName+"-data").Return("", gophercloud.ErrResourceNotFound{}),
+				storage.EXPECT().CreateVolume(ctx, gomock.Any(), gomock.Any()).Return(&volumes.Volume{ID: "volumeB"}, nil),
+				storage.EXPECT().GetVolume(ctx, "volumeB").Return(&volumes.Volume{ID: "volumeB", Status: client.VolumeStatusAvailable}, nil),
+				compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID}, nil),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:               serverID,
+					Status:           client.ServerStatusActive,
+					AvailabilityZone: "zone-b",
+				}, nil),
+			)
+			expectServerPorts()
+			expectServerAddresses(serverID)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.AvailabilityZone).To(Equal("zone-b"))
+		})
+
+		It("should use the smallest flavor meeting the requirements of the flavor selector", func() {
+			cfg.Spec.FlavorName = ""
+			cfg.Spec.FlavorSelector = &openstack.FlavorSelector{
+				MinVCPUs:   4,
+				MinRAM:     8192,
+				ExtraSpecs: map[string]string{"hw:cpu_policy": "dedicated"},
+			}
+			ex := &Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().ListFlavors(ctx).Return([]flavors.Flavor{
+				{ID: "too-small", Name: "small", VCPUs: 2, RAM: 8192},
+				{ID: "large", Name: "large", VCPUs: 8, RAM: 16384},
+				{ID: "shared", Name: "medium-shared", VCPUs: 4, RAM: 8192},
+				{ID: "medium", Name: "medium", VCPUs: 4, RAM: 16384},
+			}, nil)
+			compute.EXPECT().ListFlavorExtraSpecs(ctx, "large").Return(map[string]string{"hw:cpu_policy": "dedicated"}, nil)
+			compute.EXPECT().ListFlavorExtraSpecs(ctx, "shared").Return(map[string]string{}, nil)
+			compute.EXPECT().ListFlavorExtraSpecs(ctx, "medium").Return(map[string]string{"hw:cpu_policy": "dedicated"}, nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts servers.CreateOptsBuilder, _ servers.SchedulerHintOptsBuilder) (*servers.Server, error) {
+				createOpts := opts.(*keypairs.CreateOptsExt).CreateOptsBuilder.(*servers.CreateOpts)
+				Expect(createOpts.FlavorRef).To(Equal("medium"))
+				return &servers.Server{ID: serverID, Status: client.ServerStatusBuild}, nil
+			})
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should raise a ErrFlavorNotFound error if no flavor matches the flavor selector", func() {
+			cfg.Spec.FlavorName = ""
+			cfg.Spec.FlavorSelector = &openstack.FlavorSelector{
+				MinVCPUs: 16,
+			}
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().ListFlavors(ctx).Return([]flavors.Flavor{
+				{ID: "small", Name: "small", VCPUs: 2, RAM: 8192},
+			}, nil)
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &ErrFlavorNotFound{})).To(BeTrue())
+		})
+
+		It("should raise a ErrResourceNotFound error when called with a missing flavor", func() {
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return(flavorName, gophercloud.ErrResourceNotFound{Name: flavorName, ResourceType: "flavor"})
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrFlavorNotFound{Flavor: "flavor"})).To(BeTrue())
+			Expect(errors.As(err, &ErrFlavorNotFound{})).To(BeTrue())
+		})
+
+		It("should delete the server on failure", func() {
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			server := &servers.Server{
+				Metadata: tags,
+				ID:       serverID,
+				Name:     machineName,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
+				ID: serverID,
+			}, nil)
+
+			gomock.InOrder(
+				// we return an error to avoid waiting for the wait.Poll timeout
+				compute.EXPECT().GetServer(ctx, serverID).Return(nil, fmt.Errorf("error fetching server")),
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{*server}, nil),
+				compute.EXPECT().DeleteServer(ctx, serverID).Return(nil),
+				compute.EXPECT().GetServer(ctx, serverID).Do(func(_ context.Context, _ string) { server.Status = client.ServerStatusDeleted }).Return(server, nil),
+			)
+
+			_, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should accept multiple internal IPs", func() {
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{
+				ID: serverID,
+			}, nil)
+			gomock.InOrder(
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:     serverID,
+					Status: client.ServerStatusBuild,
+				}, nil),
+				compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{
+					ID:     serverID,
+					Status: client.ServerStatusActive,
+				}, nil))
+			expectServerPorts()
+			expectServerAddresses(serverID, serverPort(portID, networkID, serverIPv4, serverIPv6))
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.InternalIPs).To(HaveLen(2))
+			Expect(server.InternalIPs).To(ConsistOf(serverIPv4, serverIPv6))
+		})
+
+		It("should sort the internal IPs by family within each port and keep the primary port first", func() {
+			ex := &Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: machineName}).Return([]servers.Server{}, nil)
+			compute.EXPECT().ImageIDFromName(ctx, imageName).Return(images.Image{ID: "imageID"}, nil)
+			compute.EXPECT().FlavorIDFromName(ctx, flavorName).Return("flavorID", nil)
+			compute.EXPECT().CreateServer(ctx, gomock.Any(), gomock.Any()).Return(&servers.Server{ID: serverID}, nil)
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive}, nil)
+			expectServerPorts()
+			expectServerAddresses(serverID,
+				serverPort(portID, networkID, serverIPv6, serverIPv4),
+				serverPort("secondaryPortID", "secondaryNetworkID", "2000:db0::2", "10.251.0.5"),
+			)
+
+			server, err := ex.CreateMachine(ctx, machineName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.InternalIPs).To(Equal([]string{serverIPv4, serverIPv6, "10.251.0.5", "2000:db0::2"}))
+		})
+	})
+
+	Context("List", func() {
+		It("should filter the instances based on tags", func() {
+			compute.EXPECT().ListServers(ctx, gomock.Any()).Return(
+				[]servers.Server{
+					{
+						Metadata: tags,
+						ID:       "id1",
+						Name:     "foo",
+					},
+					{
+						Metadata: tags,
+						ID:       "id2",
+						Name:     "bar",
+					},
+					{
+						ID:   "baz",
+						Name: "baz",
+					},
+				},
+				nil)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			res, err := ex.ListMachines(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+			Expect(res).To(Equal(map[string]string{
+				encodeProviderID(region, "id1"): "foo",
+				encodeProviderID(region, "id2"): "bar",
+			}))
+		})
+
+		It("should encode the recorded availability zone into the provider ID", func() {
+			compute.EXPECT().ListServers(ctx, gomock.Any()).Return(
+				[]servers.Server{
+					{
+						Metadata: withServerMetadata(tags, serverMetadataAvailabilityZone, "zone-b"),
+						ID:       "id1",
+						Name:     "foo",
+					},
+				},
+				nil)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			res, err := ex.ListMachines(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal(map[string]string{
+				encodeZonalProviderID(region, "zone-b", "id1"): "foo",
+			}))
+		})
+
+		It("should filter the instances server-side by their server tags if server tags are supported", func() {
+			supportedMicroversions.Insert(client.MicroversionServerTags)
+			serverTags := []string{
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix),
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix),
+			}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Tags: strings.Join(serverTags, ",")}).Return(
+				[]servers.Server{
 					{
-						Metadata: tags,
-						ID:       "id1",
-						Name:     "foo",
+						Tags: &serverTags,
+						ID:   "id1",
+						Name: "foo",
 					},
 					{
+						Tags:     &serverTags,
 						Metadata: tags,
 						ID:       "id2",
 						Name:     "bar",
 					},
-					{
-						ID:   "baz",
-						Name: "baz",
-					},
 				},
 				nil)
 
@@ -370,7 +1670,6 @@ var _ = Describe("Executor", func() {
 
 			res, err := ex.ListMachines(ctx)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(res).To(HaveLen(2))
 			Expect(res).To(Equal(map[string]string{
 				encodeProviderID(region, "id1"): "foo",
 				encodeProviderID(region, "id2"): "bar",
@@ -414,27 +1713,590 @@ var _ = Describe("Executor", func() {
 			}
 		})
 
-		DescribeTable("#Status",
-			func(name string, expectedID string, expectedErr error) {
-				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: name}).Return(serverList, nil)
-				ex := Executor{
-					Compute: compute,
-					Network: network,
-					Config:  cfg,
-				}
-				server, err := ex.getMachineByName(ctx, name)
-				if expectedErr != nil {
-					Expect(errors.Is(err, expectedErr)).To(BeTrue())
-				} else {
-					Expect(err).ToNot(HaveOccurred())
-					Expect(server.ID).To(Equal(expectedID))
-				}
-			},
-			Entry("Should find the entry with matching metadata", "foo", "id1", nil),
-			Entry("Should return not found if name not exists", "unknown", "", ErrNotFound),
-			Entry("Should return not found if name exists without matching metadata", "baz", "", ErrNotFound),
-			Entry("Should detect multiple matching servers", "lorem", "", ErrMultipleFound),
-		)
+		DescribeTable("#Status",
+			func(name string, expectedID string, expectedErr error) {
+				compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: name}).Return(serverList, nil)
+				ex := Executor{
+					Compute: compute,
+					Network: network,
+					Config:  cfg,
+				}
+				server, err := ex.getMachineByName(ctx, name)
+				if expectedErr != nil {
+					Expect(errors.Is(err, expectedErr)).To(BeTrue())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(server.ID).To(Equal(expectedID))
+				}
+			},
+			Entry("Should find the entry with matching metadata", "foo", "id1", nil),
+			Entry("Should return not found if name not exists", "unknown", "", ErrNotFound),
+			Entry("Should return not found if name exists without matching metadata", "baz", "", ErrNotFound),
+			Entry("Should detect multiple matching servers", "lorem", "", ErrMultipleFound),
+		)
+
+		It("should report the status and addresses of the server", func() {
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return([]servers.Server{
+				{
+					Metadata: tags,
+					ID:       "id1",
+					Name:     "foo",
+					Status:   client.ServerStatusActive,
+				},
+			}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: "id1"}).Return([]ports.Port{{
+				ID:                  "portID",
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: "10.0.0.0/16"}},
+			}}, nil)
+			expectServerAddresses("id1", serverPort("portID", networkID, "10.250.0.5"))
+			cfg.Spec.PodNetworkCIDRs = []string{"10.0.0.0/16"}
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			result, err := ex.GetMachineStatus(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ProviderID).To(Equal(encodeProviderID(region, "id1")))
+			Expect(result.Status).To(Equal(client.ServerStatusActive))
+			Expect(result.Initialized).To(BeTrue())
+			Expect(result.InternalIPs).To(ConsistOf("10.250.0.5"))
+		})
+
+		It("should backfill the server tags of servers created before server tags were supported", func() {
+			supportedMicroversions.Insert(client.MicroversionServerTags)
+			compute.EXPECT().GetServer(ctx, "id").Return(&servers.Server{
+				ID:       "id",
+				Metadata: tags,
+				Status:   client.ServerStatusActive,
+			}, nil)
+			compute.EXPECT().ReplaceServerTags(ctx, "id", []string{
+				cloudprovider.ServerTagClusterPrefix + "foo",
+				cloudprovider.ServerTagRolePrefix + "foo",
+			}).Return(nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: "id"}).Return([]ports.Port{{ID: "portID", NetworkID: networkID}}, nil)
+			expectServerAddresses("id", serverPort("portID", networkID, "10.250.0.5"))
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			result, err := ex.GetMachineStatus(ctx, "foo", encodeProviderID(region, "id"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Initialized).To(BeTrue())
+		})
+
+		It("should report the server as not initialized if the pod network CIDR is not allowed on its ports", func() {
+			compute.EXPECT().GetServer(ctx, "id").Return(&servers.Server{
+				ID:       "id",
+				Metadata: tags,
+				Status:   client.ServerStatusActive,
+			}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: "id"}).Return([]ports.Port{{ID: "portID", NetworkID: networkID, FixedIPs: []ports.IP{{IPAddress: "10.250.0.5"}}}}, nil)
+			expectServerAddresses("id", serverPort("portID", networkID, "10.250.0.5"))
+			cfg.Spec.PodNetworkCIDRs = []string{"10.0.0.0/16"}
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			result, err := ex.GetMachineStatus(ctx, "foo", encodeProviderID(region, "id"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Initialized).To(BeFalse())
+		})
+
+		It("should report the addresses of the primary network first and the DNS names of the ports", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.PodNetworkCIDRs = []string{"10.0.0.0/16"}
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{
+				{Id: "storageNetworkID"},
+				{Id: networkID, PodNetwork: true},
+				{Id: "managementNetworkID", Primary: true},
+			}
+			compute.EXPECT().GetServer(ctx, "id").Return(&servers.Server{
+				ID:       "id",
+				Metadata: tags,
+				Status:   client.ServerStatusActive,
+			}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: "id"}).Return([]ports.Port{{ID: "pod", Name: "foo-1", NetworkID: networkID}}, nil)
+
+			unmanagedPort := serverPort("unmanaged", "otherNetworkID", "10.250.9.5")
+			storagePort := serverPort("storage", "storageNetworkID", "10.250.1.5")
+			storagePort.Name = "foo-0"
+			podPort := serverPort("pod", networkID, "10.250.0.5")
+			podPort.Name = "foo-1"
+			managementPort := serverPort("management", "managementNetworkID", "10.250.2.5")
+			managementPort.Name = "foo-2"
+			managementPort.DNSName = "foo"
+			managementPort.DNSAssignment = []map[string]string{
+				{"hostname": "foo", "ip_address": "10.250.2.5", "fqdn": "foo.example.com."},
+			}
+			network.EXPECT().ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: "id"}).Return([]client.PortWithDNS{unmanagedPort, podPort, managementPort, storagePort}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: "management"}).Return(nil, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: "storage"}).Return(nil, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: "pod"}).Return([]floatingips.FloatingIP{{ID: "fip", FloatingIP: "1.2.3.4"}}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: "unmanaged"}).Return(nil, nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			result, err := ex.GetMachineStatus(ctx, "foo", encodeProviderID(region, "id"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ServerAddresses).To(Equal(ServerAddresses{
+				InternalIPs: []string{"10.250.2.5", "10.250.1.5", "10.250.0.5", "10.250.9.5"},
+				ExternalIPs: []string{"1.2.3.4"},
+				Hostname:    "foo",
+				InternalDNS: []string{"foo.example.com"},
+			}))
+		})
+
+		It("should not report the addresses of a server which is still building", func() {
+			compute.EXPECT().GetServer(ctx, "id").Return(&servers.Server{
+				ID:       "id",
+				Metadata: tags,
+				Status:   client.ServerStatusBuild,
+			}, nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			result, err := ex.GetMachineStatus(ctx, "foo", encodeProviderID(region, "id"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ServerAddresses).To(BeZero())
+		})
+
+		It("should find the server by ProviderID if supplied", func() {
+			compute.EXPECT().GetServer(ctx, "id").Return(&servers.Server{
+				ID:       "id",
+				Metadata: tags,
+				Status:   client.ServerStatusError,
+				Fault:    servers.Fault{Message: NoValidHost},
+			}, nil)
+			expectServerAddresses("id")
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			result, err := ex.GetMachineStatus(ctx, "foo", encodeProviderID(region, "id"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Status).To(Equal(client.ServerStatusError))
+			Expect(result.Fault).To(Equal(NoValidHost))
+		})
+
+		It("should return not found if the server is deleted", func() {
+			compute.EXPECT().GetServer(ctx, "id").Return(&servers.Server{
+				ID:       "id",
+				Metadata: tags,
+				Status:   client.ServerStatusDeleted,
+			}, nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+
+			_, err := ex.GetMachineStatus(ctx, "foo", encodeProviderID(region, "id"))
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		})
+	})
+
+	Context("Initialize", func() {
+		var (
+			machineName = "foo"
+			serverID    = "id1"
+			portID      = "portID"
+			podCidr     = "10.0.0.0/16"
+		)
+
+		BeforeEach(func() {
+			cfg.Spec.PodNetworkCIDRs = []string{podCidr}
+		})
+
+		It("should patch the ports of the server", func() {
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				FixedIPs:            []ports.IP{{IPAddress: "10.250.0.5"}},
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: "10.1.0.0/16"}},
+			}}, nil)
+			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
+				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: "10.1.0.0/16"}, {IPAddress: podCidr}},
+			}).Return(nil)
+			network.EXPECT().TagPort(ctx, portID, []string{allowedAddressPairTagPrefix + podCidr}).Return(nil)
+			expectServerAddresses(serverID, serverPort(portID, networkID, "10.250.0.5"))
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			result, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ProviderID).To(Equal(encodeProviderID(region, serverID)))
+		})
+
+		It("should reconcile the allowed address pairs in one update guarded by the revision number", func() {
+			cfg.Spec.AllowedAddressPairs = []openstack.AllowedAddressPair{
+				{IPAddress: "10.250.0.100"},
+				{IPAddress: "10.250.0.101", MACAddress: "fa:16:3e:00:00:02"},
+			}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:             portID,
+				NetworkID:      networkID,
+				MACAddress:     "fa:16:3e:00:00:01",
+				RevisionNumber: 7,
+				FixedIPs:       []ports.IP{{IPAddress: "10.250.0.5"}},
+				Tags:           []string{"custom", allowedAddressPairTagPrefix + "10.1.0.0/16@fa:16:3e:00:00:01"},
+				AllowedAddressPairs: []ports.AddressPair{
+					{IPAddress: "10.1.0.0/16", MACAddress: "fa:16:3e:00:00:01"},
+					{IPAddress: "10.2.0.0/16", MACAddress: "fa:16:3e:00:00:01"},
+					{IPAddress: podCidr, MACAddress: "fa:16:3e:00:00:01"},
+				},
+			}}, nil)
+			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
+				AllowedAddressPairs: &[]ports.AddressPair{
+					{IPAddress: "10.2.0.0/16", MACAddress: "fa:16:3e:00:00:01"},
+					{IPAddress: podCidr, MACAddress: "fa:16:3e:00:00:01"},
+					{IPAddress: "10.250.0.100"},
+					{IPAddress: "10.250.0.101", MACAddress: "fa:16:3e:00:00:02"},
+				},
+				RevisionNumber: ptr.To(7),
+			}).Return(nil)
+			network.EXPECT().TagPort(ctx, portID, []string{
+				"custom",
+				allowedAddressPairTagPrefix + "10.250.0.100@fa:16:3e:00:00:01",
+				allowedAddressPairTagPrefix + "10.250.0.101@fa:16:3e:00:00:02",
+			}).Return(nil)
+			expectServerAddresses(serverID, serverPort(portID, networkID, "10.250.0.5"))
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should reconcile the allowed address pairs of the networks before tagging the ports", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{
+				Id:                  "storageNetworkID",
+				AllowedAddressPairs: []openstack.AllowedAddressPair{{IPAddress: "10.250.1.100"}},
+			}}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				Name:                machineName + "-0",
+				NetworkID:           "storageNetworkID",
+				FixedIPs:            []ports.IP{{IPAddress: "10.250.1.5"}},
+				Tags:                []string{allowedAddressPairTagPrefix + "10.250.1.99"},
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: "10.250.1.99"}},
+			}}, nil)
+			gomock.InOrder(
+				network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
+					AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: "10.250.1.100"}},
+				}).Return(nil),
+				network.EXPECT().TagPort(ctx, portID, []string{allowedAddressPairTagPrefix + "10.250.1.100"}).Return(nil),
+				network.EXPECT().TagPort(ctx, portID, gomock.InAnyOrder([]string{
+					allowedAddressPairTagPrefix + "10.250.1.100",
+					fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix),
+					fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix),
+				})).Return(nil),
+			)
+			expectServerAddresses(serverID)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail if the port has been modified concurrently", func() {
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:             portID,
+				NetworkID:      networkID,
+				RevisionNumber: 3,
+				FixedIPs:       []ports.IP{{IPAddress: "10.250.0.5"}},
+			}}, nil)
+			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
+				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: podCidr}},
+				RevisionNumber:      ptr.To(3),
+			}).Return(gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusPreconditionFailed})
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).To(MatchError(ContainSubstring("has been modified concurrently")))
+		})
+
+		It("should only allow the pod network CIDRs of the address families of the ports", func() {
+			cfg.Spec.PodNetworkCIDRs = []string{"fd00:10:96::/48", podCidr}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:        portID,
+				NetworkID: networkID,
+				FixedIPs:  []ports.IP{{IPAddress: "2001:db8::5"}},
+			}}, nil)
+			network.EXPECT().UpdatePort(ctx, portID, ports.UpdateOpts{
+				AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: "fd00:10:96::/48"}},
+			}).Return(nil)
+			network.EXPECT().TagPort(ctx, portID, []string{allowedAddressPairTagPrefix + "fd00:10:96::/48"}).Return(nil)
+			expectServerAddresses(serverID, serverPort(portID, networkID, "10.250.0.5", "2001:db8::5"))
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			result, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.InternalIPs).To(Equal([]string{"2001:db8::5", "10.250.0.5"}))
+		})
+
+		It("should reject a floating IP for a port with IPv6 addresses only", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "fipNetworkID"}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				FixedIPs:            []ports.IP{{IPAddress: "2001:db8::5"}},
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+
+			ex := Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).To(MatchError(ErrInvalidArgument))
+		})
+
+		It("should skip networks without port security and report the addresses of all networks in bare-metal mode", func() {
+			cfg.Spec.BareMetal = &openstack.BareMetal{}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:        portID,
+				NetworkID: networkID,
+			}}, nil)
+			network.EXPECT().GetNetworkPortSecurity(ctx, networkID).Return(false, nil)
+			expectServerAddresses(serverID, serverPort("storagePortID", "storageNetworkID", "10.250.1.10"), serverPort(portID, networkID, "10.250.0.10"))
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			result, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.InternalIPs).To(Equal([]string{"10.250.0.10", "10.250.1.10"}))
+		})
+
+		It("should tag the ports managed by MCM", func() {
+			cfg.Spec.SubnetIDs = []string{"subnetID"}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				Name:                machineName,
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+			network.EXPECT().TagPort(ctx, portID, gomock.InAnyOrder([]string{
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix),
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix),
+			})).Return(nil)
+			expectServerAddresses(serverID)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should tag the server if server tags are supported", func() {
+			supportedMicroversions.Insert(client.MicroversionServerTags)
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags, Tags: &[]string{"custom"}}, nil)
+			compute.EXPECT().ReplaceServerTags(ctx, serverID, gomock.InAnyOrder([]string{
+				"custom",
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix),
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix),
+			})).Return(nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+			expectServerAddresses(serverID)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should allocate and associate a floating IP", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "ext"}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return(nil, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{FloatingNetworkID: "ext", Description: machineName, Tags: cloudprovider.ServerTagClusterPrefix + "foo," + cloudprovider.ServerTagRolePrefix + "foo"}).Return(nil, nil)
+			network.EXPECT().CreateFloatingIP(ctx, floatingips.CreateOpts{
+				Description:       machineName,
+				FloatingNetworkID: "ext",
+				PortID:            portID,
+			}).Return(&floatingips.FloatingIP{ID: "fip", FloatingIP: "1.2.3.4"}, nil)
+			network.EXPECT().TagFloatingIP(ctx, "fip", []string{cloudprovider.ServerTagClusterPrefix + "foo", cloudprovider.ServerTagRolePrefix + "foo"}).Return(nil)
+			network.EXPECT().ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]client.PortWithDNS{serverPort(portID, networkID)}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return([]floatingips.FloatingIP{{ID: "fip", FloatingIP: "1.2.3.4"}}, nil)
+
+			ex := Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+			result, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExternalIPs).To(ConsistOf("1.2.3.4"))
+		})
+
+		It("should associate a free floating IP of the pool", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "ext", Pool: []string{"1.2.3.4", "1.2.3.5"}}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return(nil, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{FloatingNetworkID: "ext"}).Return([]floatingips.FloatingIP{
+				{ID: "fip1", FloatingIP: "1.2.3.4", PortID: "otherPort"},
+				{ID: "fip2", FloatingIP: "1.2.3.6"},
+				{ID: "fip3", FloatingIP: "1.2.3.5"},
+			}, nil)
+			network.EXPECT().UpdateFloatingIP(ctx, "fip3", floatingips.UpdateOpts{PortID: ptr.To(portID)}).Return(nil)
+			network.EXPECT().ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]client.PortWithDNS{serverPort(portID, networkID)}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return([]floatingips.FloatingIP{{ID: "fip3", FloatingIP: "1.2.3.5"}}, nil)
+
+			ex := Executor{
+				Compute:             compute,
+				Network:             network,
+				Config:              cfg,
+				AsyncServerCreation: true,
+			}
+			result, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExternalIPs).To(ConsistOf("1.2.3.5"))
+		})
+
+		It("should leave the floating IP to the machine creation if the server creation is synchronous", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "ext"}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+			network.EXPECT().ListPortsWithDNS(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]client.PortWithDNS{serverPort(portID, networkID)}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{PortID: portID}).Return([]floatingips.FloatingIP{{ID: "fip", FloatingIP: "1.2.3.4"}}, nil)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			result, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExternalIPs).To(ConsistOf("1.2.3.4"))
+		})
+
+		It("should not whitelist the pod network CIDRs on ports without port security", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{Id: networkID, PodNetwork: true, DisablePortSecurity: true}}
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:        portID,
+				Name:      machineName + "-0",
+				NetworkID: networkID,
+			}}, nil)
+			network.EXPECT().TagPort(ctx, portID, gomock.InAnyOrder([]string{
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagClusterPrefix),
+				fmt.Sprintf("%sfoo", cloudprovider.ServerTagRolePrefix),
+			})).Return(nil)
+			expectServerAddresses(serverID)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not update ports which are already set up", func() {
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusActive, Metadata: tags}, nil)
+			network.EXPECT().ListPorts(ctx, &ports.ListOpts{DeviceID: serverID}).Return([]ports.Port{{
+				ID:                  portID,
+				NetworkID:           networkID,
+				AllowedAddressPairs: []ports.AddressPair{{IPAddress: podCidr}},
+			}}, nil)
+			expectServerAddresses(serverID)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should ask for a retry if the server is still building", func() {
+			compute.EXPECT().GetServer(ctx, serverID).Return(&servers.Server{ID: serverID, Status: client.ServerStatusBuild, Metadata: tags}, nil)
+
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			_, err := ex.InitializeMachine(ctx, machineName, encodeProviderID(region, serverID))
+			Expect(errors.Is(err, ErrNotInitialized)).To(BeTrue())
+		})
 	})
 
 	Context("Delete", func() {
@@ -478,6 +2340,372 @@ var _ = Describe("Executor", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should wait for the node to be released in bare-metal mode", func() {
+			cfg.Spec.BareMetal = &openstack.BareMetal{}
+			baremetal := mocks.NewMockBaremetal(ctrl)
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			baremetal.EXPECT().ListNodes(ctx, nodes.ListOpts{InstanceUUID: "id1"}).Return([]nodes.Node{{UUID: "nodeID", InstanceUUID: "id1"}}, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(nil, gophercloud.ErrResourceNotFound{})
+			gomock.InOrder(
+				baremetal.EXPECT().GetNode(ctx, "nodeID").Return(&nodes.Node{UUID: "nodeID", ProvisionState: string(nodes.CleanWait)}, nil),
+				baremetal.EXPECT().GetNode(ctx, "nodeID").Return(&nodes.Node{UUID: "nodeID", ProvisionState: string(nodes.Available)}, nil),
+			)
+			ex := Executor{
+				Compute:      compute,
+				Network:      network,
+				Baremetal:    baremetal,
+				Config:       cfg,
+				PollInterval: time.Millisecond,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail if the cleaning of the node fails in bare-metal mode", func() {
+			cfg.Spec.BareMetal = &openstack.BareMetal{}
+			baremetal := mocks.NewMockBaremetal(ctrl)
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			baremetal.EXPECT().ListNodes(ctx, nodes.ListOpts{InstanceUUID: "id1"}).Return([]nodes.Node{{UUID: "nodeID", InstanceUUID: "id1"}}, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			baremetal.EXPECT().GetNode(ctx, "nodeID").Return(&nodes.Node{UUID: "nodeID", ProvisionState: string(nodes.CleanFail), LastError: "disk erasure failed"}, nil)
+			ex := Executor{
+				Compute:   compute,
+				Network:   network,
+				Baremetal: baremetal,
+				Config:    cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).To(MatchError(ContainSubstring("disk erasure failed")))
+		})
+
+		It("should not wait for the node if nodes are not visible in bare-metal mode", func() {
+			cfg.Spec.BareMetal = &openstack.BareMetal{}
+			baremetal := mocks.NewMockBaremetal(ctrl)
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			baremetal.EXPECT().ListNodes(ctx, nodes.ListOpts{InstanceUUID: "id1"}).Return(nil, gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusForbidden})
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			ex := Executor{
+				Compute:   compute,
+				Network:   network,
+				Baremetal: baremetal,
+				Config:    cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete the data volumes unless they are retained", func() {
+			cfg.Spec.DataVolumes = []openstack.DataVolume{
+				{Name: "data", Size: 10},
+				{Name: "cache", Size: 10, DeleteOnTermination: ptr.To(false)},
+			}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			storage.EXPECT().VolumeIDFromName(ctx, "foo-data").Return("volumeID", nil)
+			storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil)
+			storage.EXPECT().DeleteVolume(ctx, "volumeID").Return(nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).To(MatchError(ErrDeletionPending))
+		})
+
+		It("should start the deletion of all volumes before reporting the deletion as pending", func() {
+			cfg.Spec.RootDiskType = ptr.To("fast")
+			cfg.Spec.DataVolumes = []openstack.DataVolume{
+				{Name: "data", Size: 10},
+				{Name: "cache", Size: 10},
+			}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			for _, name := range []string{"foo", "foo-data", "foo-cache"} {
+				storage.EXPECT().VolumeIDFromName(ctx, name).Return(name+"-id", nil)
+				storage.EXPECT().GetVolume(ctx, name+"-id").Return(&volumes.Volume{ID: name + "-id", Status: client.VolumeStatusAvailable}, nil)
+				storage.EXPECT().DeleteVolume(ctx, name+"-id").Return(nil)
+			}
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).To(MatchError(ErrDeletionPending))
+		})
+
+		It("should delete the root volume cloned from the golden volume", func() {
+			cfg.Spec.RootVolumeSource = &openstack.RootVolumeSource{VolumeName: "golden"}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil)
+			storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil)
+			storage.EXPECT().DeleteVolume(ctx, "volumeID").Return(nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).To(MatchError(ErrDeletionPending))
+		})
+
+		It("should resume the deletion of the root volume until it is gone", func() {
+			cfg.Spec.RootDiskType = ptr.To("fast")
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(nil, nil).Times(3)
+			gomock.InOrder(
+				storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil),
+				storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusInUse}, nil),
+				storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil),
+				storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil),
+				storage.EXPECT().DeleteVolume(ctx, "volumeID").Return(nil),
+				storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil),
+				storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusDeleting}, nil),
+				storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("", gophercloud.ErrResourceNotFound{}),
+			)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			for range 3 {
+				err := ex.DeleteMachine(ctx, "foo", "")
+				Expect(err).To(MatchError(ErrDeletionPending))
+				Expect(errors.As(err, &ErrVolumeNotDeleted{})).To(BeTrue())
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should return an ErrVolumeNotDeleted if the root volume can not be deleted", func() {
+			cfg.Spec.RootDiskType = ptr.To("fast")
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil)
+			storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusErrorDeleting}, nil)
+			storage.EXPECT().DeleteVolume(ctx, "volumeID").Return(gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusBadRequest})
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(errors.As(err, &ErrVolumeNotDeleted{})).To(BeTrue())
+			Expect(err).ToNot(MatchError(ErrDeletionPending))
+		})
+
+		It("should retain the root volume", func() {
+			cfg.Spec.RootDiskType = ptr.To("fast")
+			cfg.Spec.RootVolumeDeletionPolicy = cloudprovider.RootVolumeDeletionPolicyRetain
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should back up the root volume before deleting it", func() {
+			cfg.Spec.RootDiskSize = 50
+			cfg.Spec.RootVolumeDeletionPolicy = cloudprovider.RootVolumeDeletionPolicyBackup
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(nil, nil).Times(2)
+			storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil).Times(4)
+			gomock.InOrder(
+				storage.EXPECT().ListBackups(ctx, backups.ListOpts{VolumeID: "volumeID"}).Return(nil, nil),
+				storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil),
+				storage.EXPECT().CreateBackup(ctx, backups.CreateOpts{VolumeID: "volumeID", Name: "foo"}).Return(&backups.Backup{ID: "backupID"}, nil),
+				storage.EXPECT().ListBackups(ctx, backups.ListOpts{VolumeID: "volumeID"}).Return([]backups.Backup{{ID: "backupID"}}, nil),
+				storage.EXPECT().GetBackup(ctx, "backupID").Return(&backups.Backup{ID: "backupID", Status: client.BackupStatusCreating}, nil),
+				storage.EXPECT().ListBackups(ctx, backups.ListOpts{VolumeID: "volumeID"}).Return([]backups.Backup{{ID: "backupID"}}, nil),
+				storage.EXPECT().GetBackup(ctx, "backupID").Return(&backups.Backup{ID: "backupID", Status: client.BackupStatusAvailable}, nil),
+				storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil),
+				storage.EXPECT().DeleteVolume(ctx, "volumeID").Return(nil),
+			)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			for range 3 {
+				err := ex.DeleteMachine(ctx, "foo", "")
+				Expect(err).To(MatchError(ErrDeletionPending))
+			}
+		})
+
+		It("should fail with a clear error if the backup service is missing", func() {
+			cfg.Spec.RootDiskSize = 50
+			cfg.Spec.RootVolumeDeletionPolicy = cloudprovider.RootVolumeDeletionPolicyBackup
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			storage.EXPECT().VolumeIDFromName(ctx, "foo").Return("volumeID", nil)
+			storage.EXPECT().ListBackups(ctx, backups.ListOpts{VolumeID: "volumeID"}).Return(nil, nil)
+			storage.EXPECT().GetVolume(ctx, "volumeID").Return(&volumes.Volume{ID: "volumeID", Status: client.VolumeStatusAvailable}, nil)
+			storage.EXPECT().CreateBackup(ctx, backups.CreateOpts{VolumeID: "volumeID", Name: "foo"}).Return(nil, gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusNotFound})
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Storage: storage,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).To(MatchError(ErrInvalidArgument))
+			Expect(err).To(MatchError(ContainSubstring("requires the Cinder backup service")))
+		})
+
+		It("should delete the staged user data", func() {
+			objectStorage := mocks.NewMockObjectStorage(ctrl)
+			cfg.Spec.UserDataOptions = &openstack.UserDataOptions{SwiftContainer: "userdata"}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			objectStorage.EXPECT().DeleteObject(ctx, "userdata", "foo").Return(gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusNotFound})
+			ex := Executor{
+				Compute:       compute,
+				Network:       network,
+				ObjectStorage: objectStorage,
+				Config:        cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should keep the managed server group while it has members", func() {
+			cfg.Spec.ServerGroup = &openstack.ServerGroup{Name: "workers", Policy: "anti-affinity"}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			compute.EXPECT().ListServerGroups(ctx).Return([]servergroups.ServerGroup{
+				{ID: "groupID", Name: "workers", Members: []string{"id3"}},
+			}, nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete the managed server group once it has no members left", func() {
+			cfg.Spec.ServerGroup = &openstack.ServerGroup{Name: "workers", Policy: "anti-affinity"}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			compute.EXPECT().ListServerGroups(ctx).Return([]servergroups.ServerGroup{
+				{ID: "groupID", Name: "workers", Members: []string{}},
+			}, nil)
+			compute.EXPECT().DeleteServerGroup(ctx, "groupID").Return(nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete the managed ports of all networks", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{Id: "netA"}, {Id: "netB", PortNameSuffix: "storage"}}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			network.EXPECT().ListPorts(ctx, ports.ListOpts{Name: "foo-0"}).Return([]ports.Port{{ID: "port0"}}, nil)
+			network.EXPECT().DeletePort(ctx, "port0").Return(nil)
+			network.EXPECT().ListPorts(ctx, ports.ListOpts{Name: "foo-storage"}).Return([]ports.Port{{ID: "port1"}}, nil)
+			network.EXPECT().DeletePort(ctx, "port1").Return(nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should leave the ports of the networks to Nova without managed ports", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{Id: "netA"}, {Id: "netB", PortNameSuffix: "storage"}}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete the trunk before its subports and parent port", func() {
+			cfg.Spec.NetworkID = ""
+			cfg.Spec.ManagedPorts = true
+			cfg.Spec.Networks = []openstack.OpenStackNetwork{{Id: "netA"}}
+			cfg.Spec.Trunk = &openstack.Trunk{SubPorts: []openstack.SubPort{{NetworkID: "vlanNet", SegmentationID: 100}}}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			gomock.InOrder(
+				network.EXPECT().ListTrunks(ctx, trunks.ListOpts{Name: "foo"}).Return([]trunks.Trunk{{ID: "trunk"}}, nil),
+				network.EXPECT().DeleteTrunk(ctx, "trunk").Return(nil),
+				network.EXPECT().ListPorts(ctx, ports.ListOpts{Name: "foo-vlan-100"}).Return([]ports.Port{{ID: "subport"}}, nil),
+				network.EXPECT().DeletePort(ctx, "subport").Return(nil),
+				network.EXPECT().ListPorts(ctx, ports.ListOpts{Name: "foo-0"}).Return([]ports.Port{{ID: "port0"}}, nil),
+				network.EXPECT().DeletePort(ctx, "port0").Return(nil),
+			)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should release the floating IPs of the machine", func() {
+			cfg.Spec.FloatingIP = &openstack.FloatingIP{NetworkID: "ext"}
+			compute.EXPECT().ListServers(ctx, &servers.ListOpts{Name: "foo"}).Return(serverList, nil)
+			compute.EXPECT().DeleteServer(ctx, "id1").Return(nil)
+			compute.EXPECT().GetServer(ctx, "id1").Return(&servers.Server{Status: client.ServerStatusDeleted}, nil)
+			network.EXPECT().ListFloatingIPs(ctx, floatingips.ListOpts{FloatingNetworkID: "ext", Description: "foo", Tags: cloudprovider.ServerTagClusterPrefix + "foo," + cloudprovider.ServerTagRolePrefix + "foo"}).Return([]floatingips.FloatingIP{{ID: "fip"}}, nil)
+			network.EXPECT().DeleteFloatingIP(ctx, "fip").Return(nil)
+			ex := Executor{
+				Compute: compute,
+				Network: network,
+				Config:  cfg,
+			}
+			err := ex.DeleteMachine(ctx, "foo", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should try to find by ProviderID if supplied", func() {
 			id := "id"
 			gomock.InOrder(
@@ -551,3 +2779,11 @@ var _ = Describe("Executor", func() {
 		})
 	})
 })
+
+// incompressibleUserData returns random user data, which exceeds the size limit of Nova even if it is compressed.
+func incompressibleUserData() []byte {
+	userData := make([]byte, 64*1024)
+	_, err := rand.NewChaCha8([32]byte{}).Read(userData)
+	Expect(err).ToNot(HaveOccurred())
+	return userData
+}
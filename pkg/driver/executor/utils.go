@@ -5,10 +5,26 @@
 package executor
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	"k8s.io/apimachinery/pkg/util/sets"
+	netutils "k8s.io/utils/net"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
 )
 
 // encodeProviderID encodes the ID of a server.
@@ -16,6 +32,11 @@ func encodeProviderID(region string, machineID string) string {
 	return fmt.Sprintf("openstack:///%s/%s", region, machineID)
 }
 
+// encodeZonalProviderID encodes the ID of a server together with the availability zone it was created in.
+func encodeZonalProviderID(region, zone, machineID string) string {
+	return fmt.Sprintf("openstack:///%s/%s/%s", region, zone, machineID)
+}
+
 // decodeProviderID decodes a provider-encoded ID into the ID of the server.
 func decodeProviderID(id string) string {
 	splitProviderID := strings.Split(id, "/")
@@ -62,3 +83,277 @@ func findMandatoryTags(tags map[string]string) (string, string, bool) {
 	}
 	return searchClusterName, searchNodeRole, true
 }
+
+// missingTags returns the tags that are not contained in the existing tags.
+func missingTags(existing []string, tags []string) []string {
+	var missing []string
+	for _, tag := range tags {
+		if !strSliceContains(existing, tag) {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
+
+// dataVolumeName returns the name of the data volume of a machine.
+func dataVolumeName(machineName string, dataVolume api.DataVolume) string {
+	return fmt.Sprintf("%s-%s", machineName, dataVolume.Name)
+}
+
+// portName returns the name of the port MCM manages for the network at the given index of the Networks list.
+func portName(machineName string, index int, network api.OpenStackNetwork) string {
+	suffix := network.PortNameSuffix
+	if suffix == "" {
+		suffix = strconv.Itoa(index)
+	}
+	return fmt.Sprintf("%s-%s", machineName, suffix)
+}
+
+// subPortName returns the name of the port MCM manages for the given subport of the trunk of the machine.
+func subPortName(machineName string, subPort api.SubPort) string {
+	return fmt.Sprintf("%s-vlan-%d", machineName, subPort.SegmentationID)
+}
+
+// hasImageProperties returns true if the image carries all the given properties with the given values.
+func hasImageProperties(image images.Image, properties map[string]string) bool {
+	for key, value := range properties {
+		actual, ok := image.Properties[key]
+		if !ok || fmt.Sprint(actual) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// isNoValidHost returns true if the error reports that the scheduler could not find a valid host for the server.
+func isNoValidHost(err error) bool {
+	return strings.Contains(err.Error(), NoValidHost)
+}
+
+// hasExtraSpecs returns true if the extra specs contain all the required extra specs with the given values.
+func hasExtraSpecs(extraSpecs, required map[string]string) bool {
+	for key, value := range required {
+		if actual, ok := extraSpecs[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// withServerMetadata returns a copy of the server metadata with the given key set to the value.
+func withServerMetadata(metadata map[string]string, key, value string) map[string]string {
+	result := maps.Clone(metadata)
+	if result == nil {
+		result = map[string]string{}
+	}
+	result[key] = value
+	return result
+}
+
+// maxUserDataSize is the maximum size of the base64 encoded user data accepted by Nova.
+const maxUserDataSize = 65535
+
+// fitsUserDataLimit returns whether the user data does not exceed the size limit of Nova once it is base64 encoded.
+func fitsUserDataLimit(userData []byte) bool {
+	return base64.StdEncoding.EncodedLen(len(userData)) <= maxUserDataSize
+}
+
+// gzipUserData compresses the user data with gzip, which cloud-init detects and decompresses.
+func gzipUserData(userData []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(userData); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// serverGroupPolicy returns the policy of the server group, which is reported as policies list before compute API
+// microversion 2.64.
+func serverGroupPolicy(serverGroup servergroups.ServerGroup) string {
+	if serverGroup.Policy != nil {
+		return *serverGroup.Policy
+	}
+	if len(serverGroup.Policies) > 0 {
+		return serverGroup.Policies[0]
+	}
+	return ""
+}
+
+// hasMandatoryTags returns true if the server carries the cluster and role tags, either as server tags or as metadata.
+func hasMandatoryTags(server servers.Server, searchClusterName, searchNodeRole string) bool {
+	serverTags := ptr.Deref(server.Tags, nil)
+	if strSliceContains(serverTags, searchClusterName) && strSliceContains(serverTags, searchNodeRole) {
+		return true
+	}
+
+	_, nameOk := server.Metadata[searchClusterName]
+	_, roleOk := server.Metadata[searchNodeRole]
+	return nameOk && roleOk
+}
+
+// addressPairs converts the allowed address pairs of the spec to Neutron allowed address pairs.
+func addressPairs(pairs []api.AllowedAddressPair) []ports.AddressPair {
+	var result []ports.AddressPair
+	for _, pair := range pairs {
+		result = append(result, ports.AddressPair{IPAddress: pair.IPAddress, MACAddress: pair.MACAddress})
+	}
+	return result
+}
+
+// allowedAddressPairTagPrefix is the prefix of the port tags recording the allowed address pairs added by MCM. The
+// record allows removing pairs that are no longer desired without touching the pairs added by others.
+const allowedAddressPairTagPrefix = "mcm-allowed-address-pair="
+
+// allowedAddressPairTag returns the port tag recording an allowed address pair added by MCM.
+func allowedAddressPairTag(pair ports.AddressPair) string {
+	if pair.MACAddress == "" {
+		return allowedAddressPairTagPrefix + pair.IPAddress
+	}
+	return allowedAddressPairTagPrefix + pair.IPAddress + "@" + pair.MACAddress
+}
+
+// recordedAllowedAddressPairs returns the allowed address pairs recorded as added by MCM in the port tags.
+func recordedAllowedAddressPairs(tags []string) []ports.AddressPair {
+	var pairs []ports.AddressPair
+	for _, tag := range tags {
+		value, ok := strings.CutPrefix(tag, allowedAddressPairTagPrefix)
+		if !ok {
+			continue
+		}
+		ipAddress, macAddress, _ := strings.Cut(value, "@")
+		pairs = append(pairs, ports.AddressPair{IPAddress: ipAddress, MACAddress: macAddress})
+	}
+	return pairs
+}
+
+// allowedAddressPairTags returns the port tags with the record of the allowed address pairs added by MCM replaced by
+// the supplied pairs.
+func allowedAddressPairTags(tags []string, recorded []ports.AddressPair) []string {
+	var result []string
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, allowedAddressPairTagPrefix) {
+			result = append(result, tag)
+		}
+	}
+	for _, pair := range recorded {
+		result = append(result, allowedAddressPairTag(pair))
+	}
+	return result
+}
+
+// reconcileAllowedAddressPairs merges the desired allowed address pairs with the pairs of the port. Pairs recorded as
+// added by MCM are removed if they are no longer desired, while pairs added by others are kept. Pairs without a MAC
+// address are scoped to the MAC address of the port. It returns the resulting pairs, the resulting record of pairs
+// added by MCM and whether the pairs of the port have to be updated.
+func reconcileAllowedAddressPairs(port ports.Port, desired []ports.AddressPair) ([]ports.AddressPair, []ports.AddressPair, bool) {
+	normalize := func(pair ports.AddressPair) ports.AddressPair {
+		if pair.MACAddress == "" {
+			pair.MACAddress = port.MACAddress
+		}
+		return pair
+	}
+
+	desiredPairs := sets.New[ports.AddressPair]()
+	for _, pair := range desired {
+		desiredPairs.Insert(normalize(pair))
+	}
+	recordedPairs := sets.New[ports.AddressPair]()
+	for _, pair := range recordedAllowedAddressPairs(port.Tags) {
+		recordedPairs.Insert(normalize(pair))
+	}
+
+	var (
+		pairs    []ports.AddressPair
+		recorded []ports.AddressPair
+		changed  bool
+		present  = sets.New[ports.AddressPair]()
+	)
+	for _, pair := range port.AllowedAddressPairs {
+		key := normalize(pair)
+		if recordedPairs.Has(key) && !desiredPairs.Has(key) {
+			changed = true
+			continue
+		}
+		if recordedPairs.Has(key) {
+			recorded = append(recorded, key)
+		}
+		present.Insert(key)
+		pairs = append(pairs, pair)
+	}
+	for _, pair := range desired {
+		key := normalize(pair)
+		if present.Has(key) {
+			continue
+		}
+		present.Insert(key)
+		pairs = append(pairs, pair)
+		recorded = append(recorded, key)
+		changed = true
+	}
+	return pairs, recorded, changed
+}
+
+// hasFixedIPOfFamily returns true if the port has a fixed IP address of the IPv6 family if ipv6 is true, or of the IPv4
+// family otherwise.
+func hasFixedIPOfFamily(port ports.Port, ipv6 bool) bool {
+	for _, fixedIP := range port.FixedIPs {
+		if netutils.IsIPv6String(fixedIP.IPAddress) == ipv6 {
+			return true
+		}
+	}
+	return false
+}
+
+// sortIPsByFamily sorts the IP addresses stably, so that the addresses of the IPv6 family come first if ipv6First is
+// true, and the addresses of the IPv4 family otherwise.
+func sortIPsByFamily(ips []string, ipv6First bool) {
+	slices.SortStableFunc(ips, func(a, b string) int {
+		aFirst, bFirst := netutils.IsIPv6String(a) == ipv6First, netutils.IsIPv6String(b) == ipv6First
+		switch {
+		case aFirst && !bFirst:
+			return -1
+		case !aFirst && bFirst:
+			return 1
+		}
+		return 0
+	})
+}
+
+// requiresPhysicalNetwork returns whether ports with the given vNIC type are bound to a physical network, i.e. are
+// SR-IOV ports.
+func requiresPhysicalNetwork(vnicType string) bool {
+	switch vnicType {
+	case cloudprovider.VNICTypeDirect, cloudprovider.VNICTypeDirectPhysical, cloudprovider.VNICTypeMacvtap:
+		return true
+	default:
+		return false
+	}
+}
+
+// serverTemplateData is the data available to the hostname, FQDN and description templates of the provider spec.
+type serverTemplateData struct {
+	MachineName      string
+	MachineClassName string
+	Region           string
+}
+
+// renderServerTemplate renders the supplied template with the server template data. Referencing unknown values fails.
+func renderServerTemplate(name, text string, data serverTemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -17,11 +17,15 @@ var (
 // OpenstackDriver implements and handles requests via the Driver interface.
 type OpenstackDriver struct {
 	decoder runtime.Decoder
+	// asyncServerCreation disables waiting for servers to become active during the machine creation.
+	asyncServerCreation bool
 }
 
-// NewOpenstackDriver returns a new instance of the Openstack driver.
-func NewOpenstackDriver(decoder runtime.Decoder) driver.Driver {
+// NewOpenstackDriver returns a new instance of the Openstack driver. If asyncServerCreation is set, the machine creation
+// returns as soon as the server has been accepted by Nova and the server build is tracked by the machine initialization.
+func NewOpenstackDriver(decoder runtime.Decoder, asyncServerCreation bool) driver.Driver {
 	return &OpenstackDriver{
-		decoder: decoder,
+		decoder:             decoder,
+		asyncServerCreation: asyncServerCreation,
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
@@ -55,6 +56,18 @@ func mapErrorToCode(err error) codes.Code {
 		return codes.OutOfRange
 	}
 
+	if errors.Is(err, executor.ErrNotInitialized) {
+		return codes.Uninitialized
+	}
+
+	if errors.Is(err, executor.ErrDeletionPending) {
+		return codes.Unavailable
+	}
+
+	if errors.Is(err, executor.ErrInvalidArgument) {
+		return codes.InvalidArgument
+	}
+
 	if client.IsUnauthorized(err) {
 		return codes.Unauthenticated
 	}
@@ -77,3 +90,47 @@ func mapErrorMessageToCode(err error) codes.Code {
 	}
 	return codes.Internal
 }
+
+// mapServerStatusToCode maps the status of an existing server to a machine error code. Servers that are still building
+// were never initialized. Servers that failed to build can not recover and have to be replaced. Any other status is
+// reported as OK and left to the machine health checks.
+func mapServerStatusToCode(serverStatus string) codes.Code {
+	switch serverStatus {
+	case client.ServerStatusBuild:
+		return codes.Uninitialized
+	case client.ServerStatusError:
+		return codes.Internal
+	default:
+		return codes.OK
+	}
+}
+
+// nodeAddresses converts the addresses of a server to node addresses.
+func nodeAddresses(server executor.ServerAddresses) []corev1.NodeAddress {
+	var addresses []corev1.NodeAddress
+	for _, ip := range server.InternalIPs {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeInternalIP,
+			Address: ip,
+		})
+	}
+	for _, ip := range server.ExternalIPs {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeExternalIP,
+			Address: ip,
+		})
+	}
+	if server.Hostname != "" {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeHostName,
+			Address: server.Hostname,
+		})
+	}
+	for _, name := range server.InternalDNS {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeInternalDNS,
+			Address: name,
+		})
+	}
+	return addresses
+}
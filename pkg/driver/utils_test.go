@@ -12,7 +12,9 @@ import (
 	"github.com/gophercloud/gophercloud/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/driver/executor"
 )
 
@@ -40,6 +42,18 @@ var _ = Describe("Utils", func() {
 			Expect(err2).To(HaveOccurred())
 			Expect(mapErrorToCode(err1)).To(Equal(codes.NotFound))
 		})
+		It("should map executor.ErrNotInitialized error to Uninitialized error code", func() {
+			err1 := fmt.Errorf("error: %w", executor.ErrNotInitialized)
+			Expect(mapErrorToCode(err1)).To(Equal(codes.Uninitialized))
+		})
+		It("should map a pending volume deletion to Unavailable error code", func() {
+			err1 := executor.ErrVolumeNotDeleted{VolumeID: "volumeID", Err: executor.ErrDeletionPending}
+			Expect(mapErrorToCode(err1)).To(Equal(codes.Unavailable))
+		})
+		It("should map executor.ErrInvalidArgument error to InvalidArgument error code", func() {
+			err1 := fmt.Errorf("error: %w", executor.ErrInvalidArgument)
+			Expect(mapErrorToCode(err1)).To(Equal(codes.InvalidArgument))
+		})
 		It("should map gophercloud.ErrResourceNotFound error to Internal error code", func() {
 			err1 := gophercloud.ErrResourceNotFound{}
 			err2 := status.Error(mapErrorToCode(err1), err1.Error())
@@ -69,4 +83,42 @@ var _ = Describe("Utils", func() {
 			Expect(mapErrorToCode(err1)).To(Equal(codes.ResourceExhausted))
 		})
 	})
+
+	DescribeTable("mapServerStatusToCode",
+		func(serverStatus string, expectedCode codes.Code) {
+			Expect(mapServerStatusToCode(serverStatus)).To(Equal(expectedCode))
+		},
+		Entry("should map BUILD to Uninitialized", client.ServerStatusBuild, codes.Uninitialized),
+		Entry("should map ERROR to Internal", client.ServerStatusError, codes.Internal),
+		Entry("should map ACTIVE to OK", client.ServerStatusActive, codes.OK),
+		Entry("should map SHUTOFF to OK", client.ServerStatusShutoff, codes.OK),
+	)
+
+	Describe("#nodeAddresses", func() {
+		It("should return no addresses if there are no IPs", func() {
+			Expect(nodeAddresses(executor.ServerAddresses{})).To(BeNil())
+		})
+
+		It("should map internal and external IPs", func() {
+			Expect(nodeAddresses(executor.ServerAddresses{
+				InternalIPs: []string{"10.0.0.1"},
+				ExternalIPs: []string{"1.2.3.4"},
+			})).To(Equal([]corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+				{Type: corev1.NodeExternalIP, Address: "1.2.3.4"},
+			}))
+		})
+
+		It("should map the hostname and internal DNS names", func() {
+			Expect(nodeAddresses(executor.ServerAddresses{
+				InternalIPs: []string{"10.0.0.1"},
+				Hostname:    "machine",
+				InternalDNS: []string{"machine.example.com"},
+			})).To(Equal([]corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+				{Type: corev1.NodeHostName, Address: "machine"},
+				{Type: corev1.NodeInternalDNS, Address: "machine.example.com"},
+			}))
+		})
+	})
 })
@@ -4,11 +4,11 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/machine-controller-manager-provider-openstack/pkg/client (interfaces: Compute,Network,Storage)
+// Source: github.com/gardener/machine-controller-manager-provider-openstack/pkg/client (interfaces: Compute,Network,Image,Storage,ObjectStorage,Baremetal)
 //
 // Generated by this command:
 //
-//	mockgen -copyright_file=../../../hack/LICENSE_HEADER.txt -destination=./mocks.go -package=openstack github.com/gardener/machine-controller-manager-provider-openstack/pkg/client Compute,Network,Storage
+//	mockgen -copyright_file=../../../hack/LICENSE_HEADER.txt -destination=./mocks.go -package=openstack github.com/gardener/machine-controller-manager-provider-openstack/pkg/client Compute,Network,Image,Storage,ObjectStorage,Baremetal
 //
 
 // Package openstack is a generated GoMock package.
@@ -17,10 +17,19 @@ package openstack
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	client "github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
+	nodes "github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	backups "github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/backups"
 	volumes "github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	flavors "github.com/gophercloud/gophercloud/v2/openstack/compute/v2/flavors"
+	servergroups "github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servergroups"
 	servers "github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
 	images "github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	floatingips "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	provider "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/provider"
+	trunks "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/trunks"
 	ports "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	subnets "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
 	gomock "go.uber.org/mock/gomock"
@@ -65,6 +74,21 @@ func (mr *MockComputeMockRecorder) CreateServer(ctx, opts, hintOpts any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServer", reflect.TypeOf((*MockCompute)(nil).CreateServer), ctx, opts, hintOpts)
 }
 
+// CreateServerGroup mocks base method.
+func (m *MockCompute) CreateServerGroup(ctx context.Context, opts servergroups.CreateOptsBuilder) (*servergroups.ServerGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServerGroup", ctx, opts)
+	ret0, _ := ret[0].(*servergroups.ServerGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateServerGroup indicates an expected call of CreateServerGroup.
+func (mr *MockComputeMockRecorder) CreateServerGroup(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServerGroup", reflect.TypeOf((*MockCompute)(nil).CreateServerGroup), ctx, opts)
+}
+
 // DeleteServer mocks base method.
 func (m *MockCompute) DeleteServer(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +103,20 @@ func (mr *MockComputeMockRecorder) DeleteServer(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServer", reflect.TypeOf((*MockCompute)(nil).DeleteServer), ctx, id)
 }
 
+// DeleteServerGroup mocks base method.
+func (m *MockCompute) DeleteServerGroup(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServerGroup", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServerGroup indicates an expected call of DeleteServerGroup.
+func (mr *MockComputeMockRecorder) DeleteServerGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServerGroup", reflect.TypeOf((*MockCompute)(nil).DeleteServerGroup), ctx, id)
+}
+
 // FlavorIDFromName mocks base method.
 func (m *MockCompute) FlavorIDFromName(ctx context.Context, name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +132,21 @@ func (mr *MockComputeMockRecorder) FlavorIDFromName(ctx, name any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlavorIDFromName", reflect.TypeOf((*MockCompute)(nil).FlavorIDFromName), ctx, name)
 }
 
+// GetFlavor mocks base method.
+func (m *MockCompute) GetFlavor(ctx context.Context, id string) (*flavors.Flavor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlavor", ctx, id)
+	ret0, _ := ret[0].(*flavors.Flavor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlavor indicates an expected call of GetFlavor.
+func (mr *MockComputeMockRecorder) GetFlavor(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlavor", reflect.TypeOf((*MockCompute)(nil).GetFlavor), ctx, id)
+}
+
 // GetServer mocks base method.
 func (m *MockCompute) GetServer(ctx context.Context, id string) (*servers.Server, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +177,51 @@ func (mr *MockComputeMockRecorder) ImageIDFromName(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageIDFromName", reflect.TypeOf((*MockCompute)(nil).ImageIDFromName), ctx, name)
 }
 
+// ListFlavorExtraSpecs mocks base method.
+func (m *MockCompute) ListFlavorExtraSpecs(ctx context.Context, id string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlavorExtraSpecs", ctx, id)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlavorExtraSpecs indicates an expected call of ListFlavorExtraSpecs.
+func (mr *MockComputeMockRecorder) ListFlavorExtraSpecs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlavorExtraSpecs", reflect.TypeOf((*MockCompute)(nil).ListFlavorExtraSpecs), ctx, id)
+}
+
+// ListFlavors mocks base method.
+func (m *MockCompute) ListFlavors(ctx context.Context) ([]flavors.Flavor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlavors", ctx)
+	ret0, _ := ret[0].([]flavors.Flavor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlavors indicates an expected call of ListFlavors.
+func (mr *MockComputeMockRecorder) ListFlavors(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlavors", reflect.TypeOf((*MockCompute)(nil).ListFlavors), ctx)
+}
+
+// ListServerGroups mocks base method.
+func (m *MockCompute) ListServerGroups(ctx context.Context) ([]servergroups.ServerGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServerGroups", ctx)
+	ret0, _ := ret[0].([]servergroups.ServerGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServerGroups indicates an expected call of ListServerGroups.
+func (mr *MockComputeMockRecorder) ListServerGroups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServerGroups", reflect.TypeOf((*MockCompute)(nil).ListServerGroups), ctx)
+}
+
 // ListServers mocks base method.
 func (m *MockCompute) ListServers(ctx context.Context, opts servers.ListOptsBuilder) ([]servers.Server, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +237,34 @@ func (mr *MockComputeMockRecorder) ListServers(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServers", reflect.TypeOf((*MockCompute)(nil).ListServers), ctx, opts)
 }
 
+// ReplaceServerTags mocks base method.
+func (m *MockCompute) ReplaceServerTags(ctx context.Context, id string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceServerTags", ctx, id, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceServerTags indicates an expected call of ReplaceServerTags.
+func (mr *MockComputeMockRecorder) ReplaceServerTags(ctx, id, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceServerTags", reflect.TypeOf((*MockCompute)(nil).ReplaceServerTags), ctx, id, tags)
+}
+
+// SupportsMicroversion mocks base method.
+func (m *MockCompute) SupportsMicroversion(ctx context.Context, version string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsMicroversion", ctx, version)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsMicroversion indicates an expected call of SupportsMicroversion.
+func (mr *MockComputeMockRecorder) SupportsMicroversion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsMicroversion", reflect.TypeOf((*MockCompute)(nil).SupportsMicroversion), ctx, version)
+}
+
 // MockNetwork is a mock of Network interface.
 type MockNetwork struct {
 	ctrl     *gomock.Controller
@@ -163,6 +289,35 @@ func (m *MockNetwork) EXPECT() *MockNetworkMockRecorder {
 	return m.recorder
 }
 
+// AddSubports mocks base method.
+func (m *MockNetwork) AddSubports(ctx context.Context, id string, opts trunks.AddSubportsOptsBuilder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubports", ctx, id, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubports indicates an expected call of AddSubports.
+func (mr *MockNetworkMockRecorder) AddSubports(ctx, id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubports", reflect.TypeOf((*MockNetwork)(nil).AddSubports), ctx, id, opts)
+}
+
+// CreateFloatingIP mocks base method.
+func (m *MockNetwork) CreateFloatingIP(ctx context.Context, opts floatingips.CreateOptsBuilder) (*floatingips.FloatingIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFloatingIP", ctx, opts)
+	ret0, _ := ret[0].(*floatingips.FloatingIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFloatingIP indicates an expected call of CreateFloatingIP.
+func (mr *MockNetworkMockRecorder) CreateFloatingIP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFloatingIP", reflect.TypeOf((*MockNetwork)(nil).CreateFloatingIP), ctx, opts)
+}
+
 // CreatePort mocks base method.
 func (m *MockNetwork) CreatePort(ctx context.Context, opts ports.CreateOptsBuilder) (*ports.Port, error) {
 	m.ctrl.T.Helper()
@@ -178,6 +333,35 @@ func (mr *MockNetworkMockRecorder) CreatePort(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePort", reflect.TypeOf((*MockNetwork)(nil).CreatePort), ctx, opts)
 }
 
+// CreateTrunk mocks base method.
+func (m *MockNetwork) CreateTrunk(ctx context.Context, opts trunks.CreateOptsBuilder) (*trunks.Trunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrunk", ctx, opts)
+	ret0, _ := ret[0].(*trunks.Trunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrunk indicates an expected call of CreateTrunk.
+func (mr *MockNetworkMockRecorder) CreateTrunk(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrunk", reflect.TypeOf((*MockNetwork)(nil).CreateTrunk), ctx, opts)
+}
+
+// DeleteFloatingIP mocks base method.
+func (m *MockNetwork) DeleteFloatingIP(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFloatingIP", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFloatingIP indicates an expected call of DeleteFloatingIP.
+func (mr *MockNetworkMockRecorder) DeleteFloatingIP(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFloatingIP", reflect.TypeOf((*MockNetwork)(nil).DeleteFloatingIP), ctx, id)
+}
+
 // DeletePort mocks base method.
 func (m *MockNetwork) DeletePort(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -192,6 +376,50 @@ func (mr *MockNetworkMockRecorder) DeletePort(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePort", reflect.TypeOf((*MockNetwork)(nil).DeletePort), ctx, id)
 }
 
+// DeleteTrunk mocks base method.
+func (m *MockNetwork) DeleteTrunk(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrunk", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrunk indicates an expected call of DeleteTrunk.
+func (mr *MockNetworkMockRecorder) DeleteTrunk(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrunk", reflect.TypeOf((*MockNetwork)(nil).DeleteTrunk), ctx, id)
+}
+
+// GetNetworkPortSecurity mocks base method.
+func (m *MockNetwork) GetNetworkPortSecurity(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkPortSecurity", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkPortSecurity indicates an expected call of GetNetworkPortSecurity.
+func (mr *MockNetworkMockRecorder) GetNetworkPortSecurity(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkPortSecurity", reflect.TypeOf((*MockNetwork)(nil).GetNetworkPortSecurity), ctx, id)
+}
+
+// GetNetworkProviderAttributes mocks base method.
+func (m *MockNetwork) GetNetworkProviderAttributes(ctx context.Context, id string) (*provider.NetworkProviderExt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkProviderAttributes", ctx, id)
+	ret0, _ := ret[0].(*provider.NetworkProviderExt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkProviderAttributes indicates an expected call of GetNetworkProviderAttributes.
+func (mr *MockNetworkMockRecorder) GetNetworkProviderAttributes(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkProviderAttributes", reflect.TypeOf((*MockNetwork)(nil).GetNetworkProviderAttributes), ctx, id)
+}
+
 // GetSubnet mocks base method.
 func (m *MockNetwork) GetSubnet(ctx context.Context, id string) (*subnets.Subnet, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +450,21 @@ func (mr *MockNetworkMockRecorder) GroupIDFromName(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupIDFromName", reflect.TypeOf((*MockNetwork)(nil).GroupIDFromName), ctx, name)
 }
 
+// ListFloatingIPs mocks base method.
+func (m *MockNetwork) ListFloatingIPs(ctx context.Context, opts floatingips.ListOptsBuilder) ([]floatingips.FloatingIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFloatingIPs", ctx, opts)
+	ret0, _ := ret[0].([]floatingips.FloatingIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFloatingIPs indicates an expected call of ListFloatingIPs.
+func (mr *MockNetworkMockRecorder) ListFloatingIPs(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFloatingIPs", reflect.TypeOf((*MockNetwork)(nil).ListFloatingIPs), ctx, opts)
+}
+
 // ListPorts mocks base method.
 func (m *MockNetwork) ListPorts(ctx context.Context, opts ports.ListOptsBuilder) ([]ports.Port, error) {
 	m.ctrl.T.Helper()
@@ -237,6 +480,36 @@ func (mr *MockNetworkMockRecorder) ListPorts(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPorts", reflect.TypeOf((*MockNetwork)(nil).ListPorts), ctx, opts)
 }
 
+// ListPortsWithDNS mocks base method.
+func (m *MockNetwork) ListPortsWithDNS(ctx context.Context, opts ports.ListOptsBuilder) ([]client.PortWithDNS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPortsWithDNS", ctx, opts)
+	ret0, _ := ret[0].([]client.PortWithDNS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPortsWithDNS indicates an expected call of ListPortsWithDNS.
+func (mr *MockNetworkMockRecorder) ListPortsWithDNS(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPortsWithDNS", reflect.TypeOf((*MockNetwork)(nil).ListPortsWithDNS), ctx, opts)
+}
+
+// ListTrunks mocks base method.
+func (m *MockNetwork) ListTrunks(ctx context.Context, opts trunks.ListOptsBuilder) ([]trunks.Trunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrunks", ctx, opts)
+	ret0, _ := ret[0].([]trunks.Trunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrunks indicates an expected call of ListTrunks.
+func (mr *MockNetworkMockRecorder) ListTrunks(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrunks", reflect.TypeOf((*MockNetwork)(nil).ListTrunks), ctx, opts)
+}
+
 // NetworkIDFromName mocks base method.
 func (m *MockNetwork) NetworkIDFromName(ctx context.Context, name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -267,6 +540,20 @@ func (mr *MockNetworkMockRecorder) PortIDFromName(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PortIDFromName", reflect.TypeOf((*MockNetwork)(nil).PortIDFromName), ctx, name)
 }
 
+// TagFloatingIP mocks base method.
+func (m *MockNetwork) TagFloatingIP(ctx context.Context, id string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagFloatingIP", ctx, id, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagFloatingIP indicates an expected call of TagFloatingIP.
+func (mr *MockNetworkMockRecorder) TagFloatingIP(ctx, id, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagFloatingIP", reflect.TypeOf((*MockNetwork)(nil).TagFloatingIP), ctx, id, tags)
+}
+
 // TagPort mocks base method.
 func (m *MockNetwork) TagPort(ctx context.Context, id string, tags []string) error {
 	m.ctrl.T.Helper()
@@ -281,6 +568,20 @@ func (mr *MockNetworkMockRecorder) TagPort(ctx, id, tags any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagPort", reflect.TypeOf((*MockNetwork)(nil).TagPort), ctx, id, tags)
 }
 
+// UpdateFloatingIP mocks base method.
+func (m *MockNetwork) UpdateFloatingIP(ctx context.Context, id string, opts floatingips.UpdateOptsBuilder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFloatingIP", ctx, id, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFloatingIP indicates an expected call of UpdateFloatingIP.
+func (mr *MockNetworkMockRecorder) UpdateFloatingIP(ctx, id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFloatingIP", reflect.TypeOf((*MockNetwork)(nil).UpdateFloatingIP), ctx, id, opts)
+}
+
 // UpdatePort mocks base method.
 func (m *MockNetwork) UpdatePort(ctx context.Context, id string, opts ports.UpdateOptsBuilder) error {
 	m.ctrl.T.Helper()
@@ -295,6 +596,45 @@ func (mr *MockNetworkMockRecorder) UpdatePort(ctx, id, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePort", reflect.TypeOf((*MockNetwork)(nil).UpdatePort), ctx, id, opts)
 }
 
+// MockImage is a mock of Image interface.
+type MockImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageMockRecorder
+	isgomock struct{}
+}
+
+// MockImageMockRecorder is the mock recorder for MockImage.
+type MockImageMockRecorder struct {
+	mock *MockImage
+}
+
+// NewMockImage creates a new mock instance.
+func NewMockImage(ctrl *gomock.Controller) *MockImage {
+	mock := &MockImage{ctrl: ctrl}
+	mock.recorder = &MockImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImage) EXPECT() *MockImageMockRecorder {
+	return m.recorder
+}
+
+// ListImages mocks base method.
+func (m *MockImage) ListImages(ctx context.Context, opts images.ListOptsBuilder) ([]images.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", ctx, opts)
+	ret0, _ := ret[0].([]images.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockImageMockRecorder) ListImages(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockImage)(nil).ListImages), ctx, opts)
+}
+
 // MockStorage is a mock of Storage interface.
 type MockStorage struct {
 	ctrl     *gomock.Controller
@@ -319,6 +659,21 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// CreateBackup mocks base method.
+func (m *MockStorage) CreateBackup(ctx context.Context, opts backups.CreateOptsBuilder) (*backups.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBackup", ctx, opts)
+	ret0, _ := ret[0].(*backups.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBackup indicates an expected call of CreateBackup.
+func (mr *MockStorageMockRecorder) CreateBackup(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBackup", reflect.TypeOf((*MockStorage)(nil).CreateBackup), ctx, opts)
+}
+
 // CreateVolume mocks base method.
 func (m *MockStorage) CreateVolume(ctx context.Context, opts volumes.CreateOptsBuilder, hintOpts volumes.SchedulerHintOptsBuilder) (*volumes.Volume, error) {
 	m.ctrl.T.Helper()
@@ -348,6 +703,21 @@ func (mr *MockStorageMockRecorder) DeleteVolume(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolume", reflect.TypeOf((*MockStorage)(nil).DeleteVolume), ctx, id)
 }
 
+// GetBackup mocks base method.
+func (m *MockStorage) GetBackup(ctx context.Context, id string) (*backups.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackup", ctx, id)
+	ret0, _ := ret[0].(*backups.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackup indicates an expected call of GetBackup.
+func (mr *MockStorageMockRecorder) GetBackup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackup", reflect.TypeOf((*MockStorage)(nil).GetBackup), ctx, id)
+}
+
 // GetVolume mocks base method.
 func (m *MockStorage) GetVolume(ctx context.Context, id string) (*volumes.Volume, error) {
 	m.ctrl.T.Helper()
@@ -363,6 +733,21 @@ func (mr *MockStorageMockRecorder) GetVolume(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolume", reflect.TypeOf((*MockStorage)(nil).GetVolume), ctx, id)
 }
 
+// ListBackups mocks base method.
+func (m *MockStorage) ListBackups(ctx context.Context, opts backups.ListOptsBuilder) ([]backups.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackups", ctx, opts)
+	ret0, _ := ret[0].([]backups.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBackups indicates an expected call of ListBackups.
+func (mr *MockStorageMockRecorder) ListBackups(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackups", reflect.TypeOf((*MockStorage)(nil).ListBackups), ctx, opts)
+}
+
 // ListVolumes mocks base method.
 func (m *MockStorage) ListVolumes(ctx context.Context, opts volumes.ListOptsBuilder) ([]volumes.Volume, error) {
 	m.ctrl.T.Helper()
@@ -378,6 +763,21 @@ func (mr *MockStorageMockRecorder) ListVolumes(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumes", reflect.TypeOf((*MockStorage)(nil).ListVolumes), ctx, opts)
 }
 
+// SnapshotIDFromName mocks base method.
+func (m *MockStorage) SnapshotIDFromName(ctx context.Context, name string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotIDFromName", ctx, name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotIDFromName indicates an expected call of SnapshotIDFromName.
+func (mr *MockStorageMockRecorder) SnapshotIDFromName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotIDFromName", reflect.TypeOf((*MockStorage)(nil).SnapshotIDFromName), ctx, name)
+}
+
 // VolumeIDFromName mocks base method.
 func (m *MockStorage) VolumeIDFromName(ctx context.Context, name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -392,3 +792,124 @@ func (mr *MockStorageMockRecorder) VolumeIDFromName(ctx, name any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VolumeIDFromName", reflect.TypeOf((*MockStorage)(nil).VolumeIDFromName), ctx, name)
 }
+
+// MockObjectStorage is a mock of ObjectStorage interface.
+type MockObjectStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectStorageMockRecorder is the mock recorder for MockObjectStorage.
+type MockObjectStorageMockRecorder struct {
+	mock *MockObjectStorage
+}
+
+// NewMockObjectStorage creates a new mock instance.
+func NewMockObjectStorage(ctrl *gomock.Controller) *MockObjectStorage {
+	mock := &MockObjectStorage{ctrl: ctrl}
+	mock.recorder = &MockObjectStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectStorage) EXPECT() *MockObjectStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateObject mocks base method.
+func (m *MockObjectStorage) CreateObject(ctx context.Context, container, name string, content []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateObject", ctx, container, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateObject indicates an expected call of CreateObject.
+func (mr *MockObjectStorageMockRecorder) CreateObject(ctx, container, name, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateObject", reflect.TypeOf((*MockObjectStorage)(nil).CreateObject), ctx, container, name, content)
+}
+
+// CreateTempURL mocks base method.
+func (m *MockObjectStorage) CreateTempURL(ctx context.Context, container, name string, validity time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTempURL", ctx, container, name, validity)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTempURL indicates an expected call of CreateTempURL.
+func (mr *MockObjectStorageMockRecorder) CreateTempURL(ctx, container, name, validity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTempURL", reflect.TypeOf((*MockObjectStorage)(nil).CreateTempURL), ctx, container, name, validity)
+}
+
+// DeleteObject mocks base method.
+func (m *MockObjectStorage) DeleteObject(ctx context.Context, container, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObject", ctx, container, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObject indicates an expected call of DeleteObject.
+func (mr *MockObjectStorageMockRecorder) DeleteObject(ctx, container, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockObjectStorage)(nil).DeleteObject), ctx, container, name)
+}
+
+// MockBaremetal is a mock of Baremetal interface.
+type MockBaremetal struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaremetalMockRecorder
+	isgomock struct{}
+}
+
+// MockBaremetalMockRecorder is the mock recorder for MockBaremetal.
+type MockBaremetalMockRecorder struct {
+	mock *MockBaremetal
+}
+
+// NewMockBaremetal creates a new mock instance.
+func NewMockBaremetal(ctrl *gomock.Controller) *MockBaremetal {
+	mock := &MockBaremetal{ctrl: ctrl}
+	mock.recorder = &MockBaremetalMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaremetal) EXPECT() *MockBaremetalMockRecorder {
+	return m.recorder
+}
+
+// GetNode mocks base method.
+func (m *MockBaremetal) GetNode(ctx context.Context, id string) (*nodes.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNode", ctx, id)
+	ret0, _ := ret[0].(*nodes.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNode indicates an expected call of GetNode.
+func (mr *MockBaremetalMockRecorder) GetNode(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockBaremetal)(nil).GetNode), ctx, id)
+}
+
+// ListNodes mocks base method.
+func (m *MockBaremetal) ListNodes(ctx context.Context, opts nodes.ListOptsBuilder) ([]nodes.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodes", ctx, opts)
+	ret0, _ := ret[0].([]nodes.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodes indicates an expected call of ListNodes.
+func (mr *MockBaremetalMockRecorder) ListNodes(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodes", reflect.TypeOf((*MockBaremetal)(nil).ListNodes), ctx, opts)
+}